@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/influxdata/telegraf/models"
+	"github.com/influxdata/telegraf/plugins/inputs"
+	"github.com/mrmarble/datadis-telegraf-plugin/plugins/inputs/datadis"
+)
+
+var username = flag.String("username", "", "NIF of the Datadis account")
+var password = flag.String("password", "", "password of the Datadis account, or @{env:NAME}")
+var startDate = flag.String("start_date", "", "first day to export, as YYYY/MM/DD; the last week up to yesterday when both dates are omitted")
+var endDate = flag.String("end_date", "", "last day to export, as YYYY/MM/DD")
+
+// Prints the consumption of every supply of the account as CSV, without
+// running Telegraf:
+//
+//	datadis-dump -username 12345678A -password @{env:DATADIS_PASSWORD} -start_date 2021/12/01 -end_date 2021/12/31 > consumption.csv
+func main() {
+	flag.Parse()
+
+	// Start from the defaults of the plugin.
+	d := inputs.Inputs["Datadis"]().(*datadis.Datadis)
+	d.Username = datadis.Secret(*username)
+	d.Password = datadis.Secret(*password)
+	d.StartDate = *startDate
+	d.EndDate = *endDate
+	d.Log = models.NewLogger("inputs", "datadis", "")
+
+	if err := d.Init(); err != nil {
+		fmt.Fprintf(os.Stderr, "Err: %s\n", err)
+		os.Exit(1)
+	}
+	if err := d.Dump(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Err: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,103 @@
+package datadis
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+)
+
+// Account holds the credentials of an additional Datadis login.
+type Account struct {
+	Username       Secret   `toml:"username"`
+	Password       Secret   `toml:"password"`
+	PasswordFile   string   `toml:"password_file"`
+	Supplies       []Supply `toml:"supplies"`
+	TokenCacheFile string   `toml:"token_cache_file"`
+	// IncrementalStateFile keeps the incremental state of the account.
+	IncrementalStateFile string `toml:"incremental_state_file"`
+}
+
+// accountAccumulator tags every metric with the account it was gathered
+// for.
+type accountAccumulator struct {
+	telegraf.Accumulator
+	account string
+}
+
+func (a *accountAccumulator) AddFields(measurement string, fields map[string]interface{}, tags map[string]string, t ...time.Time) {
+	tagged := map[string]string{"account": a.account}
+	for key, value := range tags {
+		tagged[key] = value
+	}
+	a.Accumulator.AddFields(measurement, fields, tagged, t...)
+}
+
+func (a *accountAccumulator) AddMetric(m telegraf.Metric) {
+	m.AddTag("account", a.account)
+	a.Accumulator.AddMetric(m)
+}
+
+// forAccount returns a plugin sharing the configuration of d that logs in
+// with account. Each account keeps its own token. Every exported setting is
+// copied, the struct itself can't be as it holds locks.
+func (d *Datadis) forAccount(account Account) *Datadis {
+	child := &Datadis{now: d.now}
+	src, dst := reflect.ValueOf(d).Elem(), reflect.ValueOf(child).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		if src.Type().Field(i).PkgPath == "" {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+
+	child.Accounts = nil
+	child.Username = account.Username
+	child.Password = account.Password
+	child.PasswordFile = account.PasswordFile
+	child.Supplies = account.Supplies
+	child.TokenCacheFile = account.TokenCacheFile
+	child.IncrementalStateFile = account.IncrementalStateFile
+	// The gathers are spaced out by d, which persists their time.
+	child.GatherStateFile = ""
+	return child
+}
+
+// initAccounts sets up a plugin for every configured account.
+func (d *Datadis) initAccounts() error {
+	d.accounts = make([]*Datadis, 0, len(d.Accounts))
+	for i, account := range d.Accounts {
+		child := d.forAccount(account)
+		err := child.Init()
+		if err != nil {
+			return fmt.Errorf("accounts[%v]: %w", i, err)
+		}
+		// Metrics are tagged with the username, not its reference.
+		child.accountName, err = child.Username.Get()
+		if err != nil {
+			return fmt.Errorf("accounts[%v]: %w", i, err)
+		}
+		d.accounts = append(d.accounts, child)
+	}
+	return nil
+}
+
+// gatherAccounts gathers every account concurrently. A failing account
+// doesn't stop the others.
+func (d *Datadis) gatherAccounts(acc telegraf.Accumulator) error {
+	wg := sync.WaitGroup{}
+	for _, account := range d.accounts {
+		wg.Add(1)
+		go func(account *Datadis) {
+			defer wg.Done()
+
+			err := account.Gather(&accountAccumulator{Accumulator: acc, account: account.accountName})
+			if err != nil {
+				acc.AddError(fmt.Errorf("account %v: %w", account.accountName, err))
+			}
+		}(account)
+	}
+	wg.Wait()
+	return nil
+}
@@ -0,0 +1,119 @@
+package datadis
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestAccounts(t *testing.T) {
+	t.Setenv("DATADIS_TEST_USERNAME", "bob")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nikola-auth/tokens/login":
+			fmt.Fprint(rw, "token-"+r.URL.Query().Get("username"))
+		case "/api-private/api/get-supplies":
+			cups := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer token-")
+			fmt.Fprintf(rw, `[ { "cups" : %q, "pointType" : 5, "distributorCode" : "2" } ]`, cups)
+		case "/api-private/api/get-consumption-data":
+			fmt.Fprintf(rw, `[ {
+				"cups" : %q,
+				"date" : "2021/12/28",
+				"time" : "01:00",
+				"consumptionKWh" : 0.121,
+				"obtainMethod" : "Real"
+			  } ]`, r.URL.Query().Get("cups"))
+		}
+	}))
+	defer ts.Close()
+
+	d := Datadis{
+		BaseURL:   ts.URL,
+		Timezone:  Timezone,
+		StartDate: "2021/12/28",
+		EndDate:   "2021/12/28",
+		Accounts: []Account{
+			{Username: "alice", Password: "pass"},
+			{Username: "@{env:DATADIS_TEST_USERNAME}", Password: "pass"},
+		},
+		Log: testutil.Logger{},
+	}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	acc := testutil.Accumulator{}
+	if err := d.Gather(&acc); err != nil {
+		t.Fatal(err)
+	}
+	if len(acc.Errors) != 0 {
+		t.Fatalf("expected: no errors, got: %v", acc.Errors)
+	}
+
+	for _, account := range []string{"alice", "bob"} {
+		tags := map[string]string{"account": account, "cups": strings.ToUpper(account), "obtain_method": "real", "point_type": "5", "resolution": "hour"}
+		acc.AssertContainsTaggedFields(t, "datadis_consumption", map[string]interface{}{"kwh": 0.121, "is_estimated": false}, tags)
+	}
+}
+
+func TestAccountsRequireCredentials(t *testing.T) {
+	d := Datadis{
+		BaseURL:  URL,
+		Timezone: Timezone,
+		Accounts: []Account{{Username: "alice"}},
+		Log:      testutil.Logger{},
+	}
+	if err := d.Init(); err == nil {
+		t.Fatal("expected: error, got: nil")
+	}
+}
+
+// TestForAccount guards against options that are not passed on to the
+// accounts.
+func TestForAccount(t *testing.T) {
+	accountScoped := map[string]bool{
+		"Username":             true,
+		"Password":             true,
+		"PasswordFile":         true,
+		"Supplies":             true,
+		"TokenCacheFile":       true,
+		"IncrementalStateFile": true,
+		"GatherStateFile":      true,
+		"Accounts":             true,
+		"Log":                  true,
+	}
+
+	d := Datadis{}
+	value := reflect.ValueOf(&d).Elem()
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.PkgPath != "" || accountScoped[field.Name] {
+			continue
+		}
+		random, ok := quick.Value(field.Type, rnd)
+		if !ok {
+			t.Fatalf("cannot generate a value for %v", field.Name)
+		}
+		value.Field(i).Set(random)
+	}
+
+	child := reflect.ValueOf(d.forAccount(Account{})).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.PkgPath != "" || accountScoped[field.Name] {
+			continue
+		}
+		if !reflect.DeepEqual(value.Field(i).Interface(), child.Field(i).Interface()) {
+			t.Fatalf("expected: %v to be copied", field.Name)
+		}
+	}
+}
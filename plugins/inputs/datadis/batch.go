@@ -0,0 +1,57 @@
+package datadis
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// dailyBatch holds the consumption of a day of a series, one field per hour.
+type dailyBatch struct {
+	tags   map[string]string
+	day    time.Time
+	fields map[string]float64
+}
+
+// dailyBatches collapses readings into a metric per series and day, for
+// batch_fields.
+type dailyBatches struct {
+	batches map[string]*dailyBatch
+	order   []string
+}
+
+// add sums kwh into the field of the hour the reading at timestamp covers,
+// kwh_00 to kwh_23.
+func (b *dailyBatches) add(tags map[string]string, timestamp time.Time, kwh float64) {
+	// Readings are stamped at the end of their interval.
+	covered := timestamp.Add(-time.Nanosecond)
+	day := time.Date(covered.Year(), covered.Month(), covered.Day(), 0, 0, 0, 0, covered.Location())
+
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	key := day.String()
+	for _, name := range names {
+		key += "," + name + "=" + tags[name]
+	}
+
+	if b.batches == nil {
+		b.batches = map[string]*dailyBatch{}
+	}
+	batch, ok := b.batches[key]
+	if !ok {
+		batch = &dailyBatch{tags: tags, day: day, fields: map[string]float64{}}
+		b.batches[key] = batch
+		b.order = append(b.order, key)
+	}
+	batch.fields[fmt.Sprintf("kwh_%02d", covered.Hour())] += kwh
+}
+
+// each calls fn for every batch in the order they were first seen.
+func (b *dailyBatches) each(fn func(batch *dailyBatch)) {
+	for _, key := range b.order {
+		fn(b.batches[key])
+	}
+}
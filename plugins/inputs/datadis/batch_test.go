@@ -0,0 +1,47 @@
+package datadis
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestBatchFields(t *testing.T) {
+	var metrics []Consumption
+	for hour := 1; hour <= 24; hour++ {
+		metrics = append(metrics, Consumption{
+			Cups:         "1234",
+			Date:         "2021/12/28",
+			Time:         fmt.Sprintf("%02d:00", hour),
+			KWh:          float64(hour),
+			ObtainMethod: "Real",
+		})
+	}
+
+	d := Datadis{location: time.UTC, BatchFields: true}
+	acc := testutil.Accumulator{}
+	if err := d.aggregateMetrcs(&acc, metrics); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(acc.Metrics) != 1 {
+		t.Fatalf("expected: %d, got: %d", 1, len(acc.Metrics))
+	}
+	m := acc.Metrics[0]
+	if len(m.Fields) != 24 {
+		t.Fatalf("expected: %d fields, got: %v", 24, m.Fields)
+	}
+	for hour := 0; hour < 24; hour++ {
+		field := fmt.Sprintf("kwh_%02d", hour)
+		if m.Fields[field] != float64(hour+1) {
+			t.Fatalf("expected: %v for %v, got: %v", hour+1, field, m.Fields[field])
+		}
+	}
+
+	want := time.Date(2021, 12, 28, 0, 0, 0, 0, time.UTC)
+	if !m.Time.Equal(want) {
+		t.Fatalf("expected: %v, got: %v", want, m.Time)
+	}
+}
@@ -0,0 +1,67 @@
+package datadis
+
+import (
+	"time"
+
+	"github.com/influxdata/telegraf"
+)
+
+// addCompleteness adds the number of readings received for every supply and
+// day against the number expected at its resolution.
+func (d *Datadis) addCompleteness(acc telegraf.Accumulator, metrics []Consumption) {
+	var (
+		received = map[dailyKey]int{}
+		order    []dailyKey
+		supplies = map[string]Supply{}
+	)
+
+	for _, supply := range d.Supplies {
+		supplies[supply.Cups] = supply
+	}
+
+	for _, consumption := range metrics {
+		if consumption.Date == "" || consumption.Time == "" {
+			continue
+		}
+
+		key := dailyKey{cups: consumption.Cups, date: consumption.Date}
+		if _, ok := received[key]; !ok {
+			order = append(order, key)
+		}
+		received[key]++
+	}
+
+	for _, key := range order {
+		day, err := time.ParseInLocation(dayLayout, key.date, d.location)
+		if err != nil {
+			acc.AddError(err)
+			continue
+		}
+
+		supply, ok := supplies[key.cups]
+		if !ok {
+			supply = Supply{Cups: key.cups}
+		}
+		perHour := 1
+		if d.supplyMeasurementType(supply) == QuarterHourly {
+			perHour = 4
+		}
+
+		// Days changing to or from summer time have 23 or 25 hours.
+		next := time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, d.location)
+		expected := int(next.Sub(day)/time.Hour) * perHour
+
+		missing := expected - received[key]
+		if missing < 0 {
+			missing = 0
+		}
+
+		fields := map[string]interface{}{
+			"expected": expected,
+			"received": received[key],
+			"missing":  missing,
+		}
+		tags := map[string]string{"cups": key.cups, "date": key.date}
+		acc.AddFields(d.measurement("completeness"), fields, tags, day)
+	}
+}
@@ -0,0 +1,64 @@
+package datadis
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestCompleteness(t *testing.T) {
+	madrid, err := time.LoadLocation(Timezone)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	day := func(date string, hours int) []Consumption {
+		var metrics []Consumption
+		for hour := 1; hour <= hours; hour++ {
+			metrics = append(metrics, Consumption{Cups: "1234", Date: date, Time: fmt.Sprintf("%02d:00", hour), KWh: 0.1, ObtainMethod: "Real"})
+		}
+		return metrics
+	}
+
+	tests := []struct {
+		name            string
+		metrics         []Consumption
+		measurementType measurementType
+		want            map[string]interface{}
+	}{
+		{
+			"Should count the missing readings of a partial day",
+			day("2021/12/28", 20),
+			HOURLY,
+			map[string]interface{}{"expected": 24, "received": 20, "missing": 4},
+		},
+		{
+			"Should expect a reading per quarter hour",
+			day("2021/12/28", 24),
+			QuarterHourly,
+			map[string]interface{}{"expected": 96, "received": 24, "missing": 72},
+		},
+		{
+			"Should expect 23 readings when changing to summer time",
+			day("2021/03/28", 23),
+			HOURLY,
+			map[string]interface{}{"expected": 23, "received": 23, "missing": 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Datadis{location: madrid, MeasurementType: tt.measurementType}
+			acc := testutil.Accumulator{}
+			d.addCompleteness(&acc, tt.metrics)
+
+			if len(acc.Metrics) != 1 {
+				t.Fatalf("expected: %d, got: %d", 1, len(acc.Metrics))
+			}
+			acc.AssertContainsTaggedFields(t, "datadis_completeness", tt.want,
+				map[string]string{"cups": "1234", "date": tt.metrics[0].Date})
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package datadis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/influxdata/telegraf"
+)
+
+// ContractDetail is the contract in force for a supply.
+type ContractDetail struct {
+	Cups               string    `json:"cups"`
+	Distributor        string    `json:"distributor"`
+	Marketer           string    `json:"marketer"`
+	AccessFare         string    `json:"accessFare"`
+	TimeDiscrimination string    `json:"timeDiscrimination"`
+	ContractedPowerKW  []float64 `json:"contractedPowerkW"`
+	StartDate          string    `json:"startDate"`
+	EndDate            string    `json:"endDate"`
+}
+
+func fetchContractDetail(ctx context.Context, d *Datadis, supply Supply) ([]ContractDetail, error) {
+	contractURL := d.endpoint("get-contract-detail")
+
+	params := url.Values{
+		"cups":            {supply.Cups},
+		"distributorCode": {supply.DistributorCode},
+	}
+	d.addAuthorizedNif(params)
+	contractURL.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", contractURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	var data []ContractDetail
+	if resp.StatusCode == 200 {
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, statusError("contract detail", resp)
+	}
+
+	return data, nil
+}
+
+// cacheContractDetail requests the contracts of supply unless they are
+// already known. Contracts rarely change, so they are only requested once
+// per supply.
+func (d *Datadis) cacheContractDetail(ctx context.Context, group *supplyGroup, supply Supply) error {
+	var cached bool
+	group.locked(func() {
+		_, cached = d.contracts[supply.Cups]
+	})
+	if cached {
+		return nil
+	}
+
+	data, err := fetchContractDetail(ctx, d, supply)
+	if err != nil {
+		return err
+	}
+
+	group.locked(func() {
+		if d.contracts == nil {
+			d.contracts = make(map[string][]ContractDetail)
+		}
+		d.contracts[supply.Cups] = data
+	})
+	return nil
+}
+
+// cachedContracts returns the known contracts of every supply.
+func (d *Datadis) cachedContracts() []ContractDetail {
+	var contracts []ContractDetail
+	for _, supply := range d.Supplies {
+		contracts = append(contracts, d.contracts[supply.Cups]...)
+	}
+	return contracts
+}
+
+func (d *Datadis) addContractDetails(acc telegraf.Accumulator, contracts []ContractDetail) {
+	for _, contract := range contracts {
+		tags := map[string]string{"cups": normalizeCups(contract.Cups)}
+		if contract.AccessFare != "" {
+			tags["access_fare"] = contract.AccessFare
+		}
+		if contract.TimeDiscrimination != "" {
+			tags["time_discrimination"] = contract.TimeDiscrimination
+		}
+
+		fields := map[string]interface{}{
+			"start_date": contract.StartDate,
+			"end_date":   contract.EndDate,
+		}
+		for i, power := range contract.ContractedPowerKW {
+			fields[fmt.Sprintf("contracted_power_p%d_kw", i+1)] = power
+		}
+
+		acc.AddFields(d.measurement("contract"), fields, tags)
+	}
+}
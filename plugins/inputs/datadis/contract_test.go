@@ -0,0 +1,88 @@
+package datadis
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestFetchContractDetail(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nikola-auth/tokens/login":
+			fmt.Fprint(rw, "token")
+			return
+		case "/api-private/api/get-consumption-data":
+			fmt.Fprint(rw, "[]")
+			return
+		}
+		atomic.AddInt32(&calls, 1)
+		if r.URL.Path != "/api-private/api/get-contract-detail" {
+			t.Fatalf("unexpected path: %q", r.URL.Path)
+		}
+
+		fmt.Fprint(rw, `[ {
+			"cups" : "1234",
+			"distributor" : "UFD DISTRIBUCION ELECTRICIDAD S.A.",
+			"marketer" : "COMERCIALIZADORA",
+			"tension" : "BAJA",
+			"accessFare" : "2.0TD",
+			"province" : "MADRID",
+			"municipality" : "MADRID",
+			"postalCode" : "28001",
+			"contractedPowerkW" : [ 4.6, 3.45 ],
+			"timeDiscrimination" : "3P",
+			"modePowerControl" : "ICP",
+			"startDate" : "2021/06/01",
+			"endDate" : ""
+		  } ]`)
+	}))
+	defer ts.Close()
+
+	d := Datadis{
+		BaseURL:              ts.URL,
+		Username:             "user",
+		Password:             "pass",
+		Timezone:             Timezone,
+		StartDate:            "2021/12/28",
+		EndDate:              "2021/12/28",
+		Supplies:             []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}},
+		GatherContractDetail: true,
+		Log:                  testutil.Logger{},
+	}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	acc := testutil.Accumulator{}
+	if err := d.Gather(&acc); err != nil {
+		t.Fatal(err)
+	}
+
+	acc.AssertContainsTaggedFields(t, "datadis_contract",
+		map[string]interface{}{
+			"contracted_power_p1_kw": 4.6,
+			"contracted_power_p2_kw": 3.45,
+			"start_date":             "2021/06/01",
+			"end_date":               "",
+		},
+		map[string]string{"cups": "1234", "access_fare": "2.0TD", "time_discrimination": "3P"})
+
+	t.Run("Should cache contracts", func(t *testing.T) {
+		acc := testutil.Accumulator{}
+		if err := d.Gather(&acc); err != nil {
+			t.Fatal(err)
+		}
+		if got := len(acc.GetTelegrafMetrics()); got != 1 {
+			t.Fatalf("expected: %d, got: %d", 1, got)
+		}
+		if got := atomic.LoadInt32(&calls); got != 1 {
+			t.Fatalf("expected: %d calls, got: %d", 1, got)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package datadis
+
+import (
+	"sort"
+	"time"
+)
+
+// energyCounter accumulates the consumption of a supply across gathers.
+type energyCounter struct {
+	total float64
+	last  time.Time
+	// values holds the counter at each counted reading still within the
+	// gathered period, so that readings fetched again keep their value.
+	values map[int64]float64
+}
+
+type counterReading struct {
+	timestamp time.Time
+	kwh       float64
+	tags      map[string]string
+	counter   float64
+}
+
+// countReadings returns the readings with their kwh_counter value. Every
+// reading is counted once, the first time it is gathered. Readings gathered
+// again that are no longer tracked are left out.
+func (d *Datadis) countReadings(readings []counterReading) []counterReading {
+	if d.counters == nil {
+		d.counters = map[string]*energyCounter{}
+	}
+
+	sort.SliceStable(readings, func(i, j int) bool {
+		return readings[i].timestamp.Before(readings[j].timestamp)
+	})
+
+	bySupply := map[string][]counterReading{}
+	for _, reading := range readings {
+		cups := reading.tags["cups"]
+		bySupply[cups] = append(bySupply[cups], reading)
+	}
+
+	var counted []counterReading
+	for cups, readings := range bySupply {
+		first, latest := readings[0].timestamp, readings[len(readings)-1].timestamp
+
+		c, ok := d.counters[cups]
+		// Readings ending before the last counted one mean Datadis replaced
+		// the data, so the counter starts over.
+		if !ok || latest.Before(c.last) {
+			if ok {
+				d.Log.Debugf("Resetting kwh_counter%s", logContext("cups", cups))
+			}
+			c = &energyCounter{values: map[int64]float64{}}
+			d.counters[cups] = c
+		}
+
+		for key := range c.values {
+			if key < first.UnixNano() {
+				delete(c.values, key)
+			}
+		}
+
+		for _, reading := range readings {
+			key := reading.timestamp.UnixNano()
+			if !c.last.IsZero() && !reading.timestamp.After(c.last) {
+				value, ok := c.values[key]
+				if !ok {
+					continue
+				}
+				reading.counter = value
+			} else {
+				c.total += reading.kwh
+				c.last = reading.timestamp
+				c.values[key] = c.total
+				reading.counter = c.total
+			}
+			counted = append(counted, reading)
+		}
+	}
+	return counted
+}
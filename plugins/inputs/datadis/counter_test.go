@@ -0,0 +1,55 @@
+package datadis
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestEmitCounter(t *testing.T) {
+	d := Datadis{location: time.UTC, EmitCounter: true, Log: testutil.Logger{}}
+
+	counters := func(metrics []Consumption) map[string]float64 {
+		acc := testutil.Accumulator{}
+		if err := d.aggregateMetrcs(&acc, metrics); err != nil {
+			t.Fatal(err)
+		}
+
+		got := map[string]float64{}
+		for _, m := range acc.Metrics {
+			if counter, ok := m.Fields["kwh_counter"]; ok {
+				got[m.Time.Format("15:04")] = counter.(float64)
+			}
+		}
+		return got
+	}
+	assertCounter := func(got map[string]float64, hour string, want float64) {
+		t.Helper()
+		if math.Abs(got[hour]-want) > 1e-9 {
+			t.Fatalf("expected: %v, got: %v", want, got[hour])
+		}
+	}
+
+	first := counters([]Consumption{
+		{Cups: "1234", Date: "2021/12/28", Time: "01:00", KWh: 0.1, ObtainMethod: "Real"},
+		{Cups: "1234", Date: "2021/12/28", Time: "02:00", KWh: 0.2, ObtainMethod: "Real"},
+	})
+	assertCounter(first, "01:00", 0.1)
+	assertCounter(first, "02:00", 0.3)
+
+	second := counters([]Consumption{
+		{Cups: "1234", Date: "2021/12/28", Time: "02:00", KWh: 0.2, ObtainMethod: "Real"},
+		{Cups: "1234", Date: "2021/12/28", Time: "03:00", KWh: 0.4, ObtainMethod: "Real"},
+	})
+	assertCounter(second, "02:00", 0.3)
+	assertCounter(second, "03:00", 0.7)
+
+	t.Run("Should reset when data is replaced", func(t *testing.T) {
+		got := counters([]Consumption{
+			{Cups: "1234", Date: "2021/12/27", Time: "01:00", KWh: 0.5, ObtainMethod: "Real"},
+		})
+		assertCounter(got, "01:00", 0.5)
+	})
+}
@@ -0,0 +1,43 @@
+package datadis
+
+import (
+	"time"
+
+	"github.com/influxdata/telegraf"
+)
+
+type dailyKey struct {
+	cups string
+	date string
+}
+
+// addDailyTotals adds the consumption of every supply summed by day.
+func (d *Datadis) addDailyTotals(acc telegraf.Accumulator, metrics []Consumption) {
+	var (
+		totals = map[dailyKey]float64{}
+		order  []dailyKey
+	)
+
+	for _, consumption := range metrics {
+		if consumption.Date == "" || !d.keepObtainMethod(consumption.ObtainMethod) {
+			continue
+		}
+
+		key := dailyKey{cups: consumption.Cups, date: consumption.Date}
+		if _, ok := totals[key]; !ok {
+			order = append(order, key)
+		}
+		totals[key] += consumption.KWh
+	}
+
+	for _, key := range order {
+		timestamp, err := time.ParseInLocation(dayLayout, key.date, d.location)
+		if err != nil {
+			acc.AddError(err)
+			continue
+		}
+
+		tags := map[string]string{"cups": key.cups, "date": key.date}
+		acc.AddFields(d.measurement("daily_total"), map[string]interface{}{"kwh_total": totals[key]}, tags, timestamp)
+	}
+}
@@ -0,0 +1,41 @@
+package datadis
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestDailyTotals(t *testing.T) {
+	metrics := []Consumption{
+		{Cups: "1234", Date: "2021/12/28", Time: "01:00", KWh: 0.121, ObtainMethod: "Real"},
+		{Cups: "1234", Date: "2021/12/28", Time: "02:00", KWh: 0.117, ObtainMethod: "Real"},
+		{Cups: "1234", Date: "2021/12/28", Time: "24:00", KWh: 0.302, ObtainMethod: "Real"},
+		{Cups: "1234", Date: "2021/12/29", Time: "01:00", KWh: 0.250, ObtainMethod: "Real"},
+	}
+
+	d := Datadis{location: time.UTC}
+	acc := testutil.Accumulator{}
+	d.addDailyTotals(&acc, metrics)
+
+	if len(acc.Metrics) != 2 {
+		t.Fatalf("expected: %d, got: %d", 2, len(acc.Metrics))
+	}
+
+	m := acc.Metrics[0]
+	if m.Measurement != "datadis_daily_total" {
+		t.Fatalf("expected: %q, got: %q", "datadis_daily_total", m.Measurement)
+	}
+	if m.Tags["cups"] != "1234" || m.Tags["date"] != "2021/12/28" {
+		t.Fatalf("unexpected tags: %v", m.Tags)
+	}
+	if total := m.Fields["kwh_total"].(float64); math.Abs(total-0.540) > 1e-9 {
+		t.Fatalf("expected: %v, got: %v", 0.540, total)
+	}
+	want := time.Date(2021, 12, 28, 0, 0, 0, 0, time.UTC)
+	if !m.Time.Equal(want) {
+		t.Fatalf("expected: %v, got: %v", want, m.Time)
+	}
+}
@@ -0,0 +1,60 @@
+package datadis
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const redacted = "[redacted]"
+
+// traceRequest logs the URL and headers of req, without credentials.
+func (d *Datadis) traceRequest(req *http.Request) {
+	traced := *req.URL
+	query := traced.Query()
+	if query.Get("password") != "" {
+		query.Set("password", redacted)
+		traced.RawQuery = query.Encode()
+	}
+
+	headers := req.Header.Clone()
+	if headers.Get("Authorization") != "" {
+		headers.Set("Authorization", "Bearer "+redacted)
+	}
+	d.Log.Debugf("HTTP request%s", logContext("method", req.Method, "url", traced.String(), "headers", formatHeaders(headers)))
+}
+
+// traceResponse logs the status and the start of the body of resp, which is
+// left intact for the caller. The body of the login, the token, is never
+// logged.
+func (d *Datadis) traceResponse(req *http.Request, resp *http.Response) {
+	if req.URL.Path == d.loginURL().Path {
+		d.Log.Debugf("HTTP response%s", logContext("status", resp.Status, "path", req.URL.Path, "body", redacted))
+		return
+	}
+
+	peek, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(peek), resp.Body), resp.Body}
+
+	d.Log.Debugf("HTTP response%s", logContext("status", resp.Status, "path", req.URL.Path, "body", string(bytes.TrimSpace(peek))))
+}
+
+// formatHeaders lists headers sorted by name.
+func formatHeaders(headers http.Header) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	formatted := make([]string, 0, len(names))
+	for _, name := range names {
+		formatted = append(formatted, name+": "+strings.Join(headers[name], ","))
+	}
+	return strings.Join(formatted, ", ")
+}
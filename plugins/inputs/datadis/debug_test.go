@@ -0,0 +1,65 @@
+package datadis
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestDebugHTTP(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/nikola-auth/tokens/login" {
+			fmt.Fprint(rw, "secret-token")
+			return
+		}
+		fmt.Fprint(rw, `[ {
+			"cups" : "1234",
+			"date" : "2021/12/28",
+			"time" : "01:00",
+			"consumptionKWh" : 0.121,
+			"obtainMethod" : "Real"
+		  } ]`)
+	}))
+	defer ts.Close()
+
+	log := &recordingLogger{}
+	d := Datadis{
+		BaseURL:   ts.URL,
+		Username:  "user",
+		Password:  "secret-password",
+		Timezone:  Timezone,
+		StartDate: "2021/12/28",
+		EndDate:   "2021/12/28",
+		Supplies:  []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}},
+		DebugHTTP: true,
+		Log:       log,
+	}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	acc := testutil.Accumulator{}
+	if err := d.Gather(&acc); err != nil {
+		t.Fatal(err)
+	}
+	if len(acc.Metrics) != 1 {
+		t.Fatalf("expected: %d, got: %d", 1, len(acc.Metrics))
+	}
+
+	output := log.output()
+	if !strings.Contains(output, "/api-private/api/get-consumption-data") {
+		t.Fatalf("expected %v in the log, got: %v", "/api-private/api/get-consumption-data", output)
+	}
+	if !strings.Contains(output, "Bearer [redacted]") {
+		t.Fatalf("expected a redacted token, got: %v", output)
+	}
+	for _, secret := range []string{"secret-token", "secret-password"} {
+		if strings.Contains(output, secret) {
+			t.Fatalf("expected %v not to be logged, got: %v", secret, output)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package datadis
+
+import "time"
+
+type seenKey struct {
+	cups      string
+	timestamp int64
+}
+
+// alreadyEmitted reports whether the reading was emitted by a previous
+// gather with the same values, and remembers it otherwise.
+func (d *Datadis) alreadyEmitted(consumption Consumption, timestamp time.Time) bool {
+	if d.seen == nil {
+		d.seen = map[seenKey]Consumption{}
+	}
+
+	key := seenKey{cups: consumption.Cups, timestamp: timestamp.UnixNano()}
+	if previous, ok := d.seen[key]; ok && previous == consumption {
+		return true
+	}
+	d.seen[key] = consumption
+	return false
+}
+
+// forgetBefore drops the readings older than oldest, which are no longer
+// requested.
+func (d *Datadis) forgetBefore(oldest time.Time) {
+	for key := range d.seen {
+		if key.timestamp < oldest.UnixNano() {
+			delete(d.seen, key)
+		}
+	}
+}
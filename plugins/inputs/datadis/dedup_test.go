@@ -0,0 +1,49 @@
+package datadis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestDeduplicate(t *testing.T) {
+	d := Datadis{location: time.UTC, Deduplicate: true}
+
+	gather := func(metrics []Consumption) []map[string]interface{} {
+		acc := testutil.Accumulator{}
+		if err := d.aggregateMetrcs(&acc, metrics); err != nil {
+			t.Fatal(err)
+		}
+
+		var fields []map[string]interface{}
+		for _, m := range acc.Metrics {
+			fields = append(fields, m.Fields)
+		}
+		return fields
+	}
+
+	window := []Consumption{
+		{Cups: "1234", Date: "2021/12/28", Time: "01:00", KWh: 0.1, ObtainMethod: "Real"},
+		{Cups: "1234", Date: "2021/12/28", Time: "02:00", KWh: 0.2, ObtainMethod: "Real"},
+	}
+
+	if got := gather(window); len(got) != 2 {
+		t.Fatalf("expected: %d, got: %d", 2, len(got))
+	}
+	if got := gather(window); len(got) != 0 {
+		t.Fatalf("expected: %d, got: %v", 0, got)
+	}
+
+	t.Run("Should emit changed readings", func(t *testing.T) {
+		changed := []Consumption{
+			{Cups: "1234", Date: "2021/12/28", Time: "01:00", KWh: 0.1, ObtainMethod: "Real"},
+			{Cups: "1234", Date: "2021/12/28", Time: "02:00", KWh: 0.25, ObtainMethod: "Real"},
+		}
+
+		got := gather(changed)
+		if len(got) != 1 || got[0]["kwh"] != 0.25 {
+			t.Fatalf("expected: %v, got: %v", []map[string]interface{}{{"kwh": 0.25}}, got)
+		}
+	})
+}
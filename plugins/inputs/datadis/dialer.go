@@ -0,0 +1,43 @@
+package datadis
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// defaultDialTimeout matches the dialer of http.DefaultTransport.
+const defaultDialTimeout = 30 * time.Second
+
+// contextDialer opens the connections to Datadis.
+type contextDialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// dialContext connects with the configured dialer, forcing dial_network
+// when set. Cancelling ctx aborts the dial.
+func (d *Datadis) dialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := d.dialer
+	if dialer == nil {
+		timeout := time.Duration(d.DialTimeout)
+		if timeout <= 0 {
+			timeout = defaultDialTimeout
+		}
+		dialer = &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}
+	}
+
+	if d.DialNetwork != "" {
+		network = d.DialNetwork
+	}
+	return dialer.DialContext(ctx, network, address)
+}
+
+// validateDialNetwork checks dial_network.
+func validateDialNetwork(network string) error {
+	switch network {
+	case "", "tcp", "tcp4", "tcp6":
+		return nil
+	}
+	return fmt.Errorf(`invalid dial_network %q: must be "tcp", "tcp4" or "tcp6"`, network)
+}
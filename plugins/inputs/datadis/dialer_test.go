@@ -0,0 +1,63 @@
+package datadis
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+// recordingDialer dials over TCP, recording the requested networks.
+type recordingDialer struct {
+	networks []string
+}
+
+func (r *recordingDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	r.networks = append(r.networks, network)
+	return (&net.Dialer{}).DialContext(ctx, "tcp", address)
+}
+
+func TestDialer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(rw, "[]")
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name    string
+		network string
+		want    string
+	}{
+		{"Should dial with the default network", "", "tcp"},
+		{"Should dial with the configured network", "tcp4", "tcp4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialer := &recordingDialer{}
+			d := Datadis{BaseURL: ts.URL, DialNetwork: tt.network, dialer: dialer, Log: testutil.Logger{}}
+			client, err := d.createHTTPClient()
+			if err != nil {
+				t.Fatal(err)
+			}
+			d.httpClient = client
+
+			if _, err := fetchConsumption(context.Background(), &d, Supply{}); err != nil {
+				t.Fatal(err)
+			}
+			if len(dialer.networks) == 0 || dialer.networks[0] != tt.want {
+				t.Fatalf("expected: %v, got: %v", tt.want, dialer.networks)
+			}
+		})
+	}
+
+	t.Run("Should reject unknown networks", func(t *testing.T) {
+		if err := validateDialNetwork("udp"); err == nil {
+			t.Fatal("expected: error, got: nil")
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package datadis
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Distributors lists the distributor codes the user can query.
+type Distributors struct {
+	DistributorCodes []string `json:"distributorCodes"`
+}
+
+func fetchDistributors(ctx context.Context, d *Datadis) ([]string, error) {
+	distributorsURL := d.endpoint("get-distributors")
+
+	params := url.Values{}
+	d.addAuthorizedNif(params)
+	distributorsURL.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", distributorsURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	var data Distributors
+	if resp.StatusCode == 200 {
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, statusError("distributors", resp)
+	}
+
+	return data.DistributorCodes, nil
+}
+
+// resolveDistributorCodes fills in the distributor code of the configured
+// supplies that omit it. The code can only be told apart when the user has
+// a single distributor.
+func (d *Datadis) resolveDistributorCodes(ctx context.Context) error {
+	var missing []int
+	for i, supply := range d.Supplies {
+		if supply.DistributorCode == "" {
+			missing = append(missing, i)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	codes, err := fetchDistributors(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	for _, i := range missing {
+		if len(codes) != 1 {
+			d.Log.Warnf("Could not resolve the distributor code%s", logContext("cups", d.Supplies[i].Cups, "distributors", strings.Join(codes, ",")))
+			continue
+		}
+		d.Supplies[i].DistributorCode = codes[0]
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package datadis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestResolveDistributorCodes(t *testing.T) {
+	var payload string
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api-private/api/get-distributors" {
+			t.Fatalf("unexpected path: %q", r.URL.Path)
+		}
+		fmt.Fprint(rw, payload)
+	}))
+	defer ts.Close()
+
+	t.Run("Should decode distributors", func(t *testing.T) {
+		payload = `{ "distributorCodes" : [ "2", "8" ] }`
+		d := Datadis{BaseURL: ts.URL, httpClient: ts.Client()}
+
+		got, err := fetchDistributors(context.Background(), &d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 || got[0] != "2" || got[1] != "8" {
+			t.Fatalf("expected: %v, got: %v", []string{"2", "8"}, got)
+		}
+	})
+	t.Run("Should fill in missing code", func(t *testing.T) {
+		payload = `{ "distributorCodes" : [ "2" ] }`
+		d := Datadis{
+			BaseURL:    ts.URL,
+			httpClient: ts.Client(),
+			Supplies:   []Supply{{Cups: "1234"}, {Cups: "5678", DistributorCode: "8"}},
+			Log:        testutil.Logger{},
+		}
+
+		if err := d.resolveDistributorCodes(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if d.Supplies[0].DistributorCode != "2" {
+			t.Fatalf("expected: %q, got: %q", "2", d.Supplies[0].DistributorCode)
+		}
+		if d.Supplies[1].DistributorCode != "8" {
+			t.Fatalf("expected: %q, got: %q", "8", d.Supplies[1].DistributorCode)
+		}
+	})
+	t.Run("Should warn when ambiguous", func(t *testing.T) {
+		payload = `{ "distributorCodes" : [ "2", "8" ] }`
+		log := &recordingLogger{}
+		d := Datadis{
+			BaseURL:    ts.URL,
+			httpClient: ts.Client(),
+			Supplies:   []Supply{{Cups: "1234"}},
+			Log:        log,
+		}
+
+		if err := d.resolveDistributorCodes(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if d.Supplies[0].DistributorCode != "" {
+			t.Fatalf("expected: %q, got: %q", "", d.Supplies[0].DistributorCode)
+		}
+		if len(log.messages) != 1 {
+			t.Fatalf("expected: a warning, got: %v", log.messages)
+		}
+	})
+}
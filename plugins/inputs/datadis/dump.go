@@ -0,0 +1,49 @@
+package datadis
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// Dump logs in and writes the consumption of every supply as CSV to w, to
+// export the readings without Telegraf. Init must be called first.
+func (d *Datadis) Dump(w io.Writer) error {
+	ctx := context.Background()
+	err := d.initializeClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	consumptions, errs := d.fetchAllConsumptions(ctx)
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return writeCSV(w, consumptions)
+}
+
+// writeCSV writes consumptions to w, one reading per row after a header.
+func writeCSV(w io.Writer, consumptions []Consumption) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{"cups", "date", "time", "kwh", "obtain_method"})
+	if err != nil {
+		return err
+	}
+
+	for _, consumption := range consumptions {
+		err = writer.Write([]string{
+			consumption.Cups,
+			consumption.Date,
+			consumption.Time,
+			strconv.FormatFloat(consumption.KWh, 'f', -1, 64),
+			normalizeObtainMethod(consumption.ObtainMethod),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,24 @@
+package datadis
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeCSV(&buf, []Consumption{
+		{Cups: "1234", Date: "2021/12/28", Time: "01:00", KWh: 0.121, ObtainMethod: "Real"},
+		{Cups: "1234", Date: "2021/12/28", Time: "02:00", KWh: 1, ObtainMethod: "Estimada"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "cups,date,time,kwh,obtain_method\n" +
+		"1234,2021/12/28,01:00,0.121,real\n" +
+		"1234,2021/12/28,02:00,1,estimated\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("expected: %q, got: %q", want, got)
+	}
+}
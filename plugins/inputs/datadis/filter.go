@@ -0,0 +1,66 @@
+package datadis
+
+import (
+	"time"
+
+	"github.com/influxdata/telegraf"
+)
+
+// exclusionFilter drops the tags and fields listed in exclude_tags and
+// exclude_fields from every metric. The shim doesn't apply the tagexclude
+// and fieldpass of Telegraf to the plugin.
+type exclusionFilter struct {
+	telegraf.Accumulator
+	tags   map[string]bool
+	fields map[string]bool
+}
+
+// newExclusionFilter wraps acc, or returns it unchanged when nothing is
+// excluded.
+func (d *Datadis) newExclusionFilter(acc telegraf.Accumulator) telegraf.Accumulator {
+	if len(d.ExcludeTags) == 0 && len(d.ExcludeFields) == 0 {
+		return acc
+	}
+
+	filter := &exclusionFilter{Accumulator: acc, tags: map[string]bool{}, fields: map[string]bool{}}
+	for _, tag := range d.ExcludeTags {
+		filter.tags[tag] = true
+	}
+	for _, field := range d.ExcludeFields {
+		filter.fields[field] = true
+	}
+	return filter
+}
+
+func (f *exclusionFilter) AddFields(measurement string, fields map[string]interface{}, tags map[string]string, t ...time.Time) {
+	kept := map[string]interface{}{}
+	for key, value := range fields {
+		if !f.fields[key] {
+			kept[key] = value
+		}
+	}
+	if len(kept) == 0 {
+		return
+	}
+
+	keptTags := map[string]string{}
+	for key, value := range tags {
+		if !f.tags[key] {
+			keptTags[key] = value
+		}
+	}
+	f.Accumulator.AddFields(measurement, kept, keptTags, t...)
+}
+
+func (f *exclusionFilter) AddMetric(m telegraf.Metric) {
+	for tag := range f.tags {
+		m.RemoveTag(tag)
+	}
+	for field := range f.fields {
+		m.RemoveField(field)
+	}
+	if len(m.FieldList()) == 0 {
+		return
+	}
+	f.Accumulator.AddMetric(m)
+}
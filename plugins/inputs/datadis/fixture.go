@@ -0,0 +1,42 @@
+package datadis
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// readFixture decodes the consumption saved in fixture_file, a response of
+// get-consumption-data, in place of requesting it from Datadis.
+func (d *Datadis) readFixture() ([]Consumption, error) {
+	data, err := os.ReadFile(d.FixtureFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var consumptions []Consumption
+	err = json.Unmarshal(data, &consumptions)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding fixture_file %q: %w", d.FixtureFile, err)
+	}
+	return d.filterFixture(consumptions), nil
+}
+
+// filterFixture keeps the readings of the supplies listed in cups_filter, as
+// for the supplies of the account.
+func (d *Datadis) filterFixture(consumptions []Consumption) []Consumption {
+	if len(d.CupsFilter) == 0 {
+		return consumptions
+	}
+
+	var filtered []Consumption
+	for _, consumption := range consumptions {
+		for _, cups := range d.CupsFilter {
+			if consumption.Cups == cups {
+				filtered = append(filtered, consumption)
+				break
+			}
+		}
+	}
+	return filtered
+}
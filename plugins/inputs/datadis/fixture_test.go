@@ -0,0 +1,93 @@
+package datadis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestFixtureFile(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "consumption.json")
+	err := os.WriteFile(fixture, []byte(`[ {
+		"cups" : "ES0099999999999999AAAA",
+		"date" : "2021/12/28",
+		"time" : "01:00",
+		"consumptionKWh" : 0.121,
+		"obtainMethod" : "Real"
+	  }, {
+		"cups" : "ES0099999999999999BBBB",
+		"date" : "2021/12/28",
+		"time" : "02:00",
+		"consumptionKWh" : 0.2,
+		"obtainMethod" : "Estimada"
+	  } ]`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := Datadis{
+		BaseURL:     URL,
+		Timezone:    Timezone,
+		FixtureFile: fixture,
+		Log:         testutil.Logger{},
+	}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	acc := testutil.Accumulator{}
+	if err := d.Gather(&acc); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(acc.Metrics) != 2 {
+		t.Fatalf("expected: %d, got: %d", 2, len(acc.Metrics))
+	}
+	acc.AssertContainsTaggedFields(t, "datadis_consumption",
+		map[string]interface{}{"kwh": 0.121, "is_estimated": false},
+		map[string]string{"cups": "ES0099999999999999AAAA", "obtain_method": "real", "resolution": "hour"})
+	acc.AssertContainsTaggedFields(t, "datadis_consumption",
+		map[string]interface{}{"kwh": 0.2, "is_estimated": true},
+		map[string]string{"cups": "ES0099999999999999BBBB", "obtain_method": "estimated", "resolution": "hour"})
+
+	want := time.Date(2021, 12, 28, 1, 0, 0, 0, d.location)
+	if !acc.Metrics[0].Time.Equal(want) {
+		t.Fatalf("expected: %v, got: %v", want, acc.Metrics[0].Time)
+	}
+
+	t.Run("Should only emit the supplies in cups_filter", func(t *testing.T) {
+		d := Datadis{
+			BaseURL:     URL,
+			Timezone:    Timezone,
+			FixtureFile: fixture,
+			CupsFilter:  []string{"ES0099999999999999BBBB"},
+			Log:         testutil.Logger{},
+		}
+		if err := d.Init(); err != nil {
+			t.Fatal(err)
+		}
+
+		acc := testutil.Accumulator{}
+		if err := d.Gather(&acc); err != nil {
+			t.Fatal(err)
+		}
+		if len(acc.Metrics) != 1 || acc.Metrics[0].Tags["cups"] != "ES0099999999999999BBBB" {
+			t.Fatalf("expected: %v, got: %v", "ES0099999999999999BBBB", acc.Metrics)
+		}
+	})
+
+	t.Run("Should fail on a missing fixture", func(t *testing.T) {
+		d := Datadis{
+			BaseURL:     URL,
+			Timezone:    Timezone,
+			FixtureFile: filepath.Join(t.TempDir(), "missing.json"),
+			Log:         testutil.Logger{},
+		}
+		if err := d.Init(); err == nil {
+			t.Fatal("expected: an error, got: none")
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package datadis
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/influxdata/telegraf"
+)
+
+// gatherTimeStamper stamps every reading with the gather time, moving the
+// reading time to the original_time field, for outputs that reject
+// backdated points. The reading time is kept in the original_time tag as
+// well, as the readings of a series would otherwise share a timestamp and
+// overwrite each other.
+type gatherTimeStamper struct {
+	telegraf.Accumulator
+	now time.Time
+}
+
+// newGatherTimeStamper wraps acc, or returns it unchanged when readings keep
+// their own time.
+func (d *Datadis) newGatherTimeStamper(acc telegraf.Accumulator, now time.Time) telegraf.Accumulator {
+	if !d.UseGatherTime {
+		return acc
+	}
+	return &gatherTimeStamper{Accumulator: acc, now: now}
+}
+
+func (s *gatherTimeStamper) AddFields(measurement string, fields map[string]interface{}, tags map[string]string, t ...time.Time) {
+	// Metrics without a time are already stamped when gathered.
+	if len(t) == 0 {
+		s.Accumulator.AddFields(measurement, fields, tags)
+		return
+	}
+
+	stamped := make(map[string]interface{}, len(fields)+1)
+	for key, value := range fields {
+		stamped[key] = value
+	}
+	stamped["original_time"] = t[0].Unix()
+	tagged := make(map[string]string, len(tags)+1)
+	for key, value := range tags {
+		tagged[key] = value
+	}
+	tagged["original_time"] = strconv.FormatInt(t[0].Unix(), 10)
+	s.Accumulator.AddFields(measurement, stamped, tagged, s.now)
+}
+
+func (s *gatherTimeStamper) AddMetric(m telegraf.Metric) {
+	m.AddField("original_time", m.Time().Unix())
+	m.AddTag("original_time", strconv.FormatInt(m.Time().Unix(), 10))
+	m.SetTime(s.now)
+	s.Accumulator.AddMetric(m)
+}
@@ -0,0 +1,98 @@
+package datadis
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestUseGatherTime(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/nikola-auth/tokens/login" {
+			fmt.Fprint(rw, "token")
+			return
+		}
+		fmt.Fprint(rw, `[ {
+			"cups" : "1234",
+			"date" : "2021/12/28",
+			"time" : "01:00",
+			"consumptionKWh" : 0.121,
+			"obtainMethod" : "Real"
+		  }, {
+			"cups" : "1234",
+			"date" : "2021/12/28",
+			"time" : "02:00",
+			"consumptionKWh" : 0.103,
+			"obtainMethod" : "Real"
+		  } ]`)
+	}))
+	defer ts.Close()
+
+	gatherTime := time.Date(2022, 1, 3, 10, 0, 0, 0, time.UTC)
+	newPlugin := func(useGatherTime bool) *Datadis {
+		return &Datadis{
+			BaseURL:       ts.URL,
+			Username:      "user",
+			Password:      "pass",
+			Timezone:      Timezone,
+			StartDate:     "2021/12/28",
+			EndDate:       "2021/12/28",
+			Supplies:      []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}},
+			UseGatherTime: useGatherTime,
+			now:           func() time.Time { return gatherTime },
+			Log:           testutil.Logger{},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		useGatherTime bool
+	}{
+		{"Should stamp the readings with the gather time", true},
+		{"Should stamp the readings with their own time by default", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newPlugin(tt.useGatherTime)
+			if err := d.Init(); err != nil {
+				t.Fatal(err)
+			}
+
+			acc := testutil.Accumulator{}
+			if err := d.Gather(&acc); err != nil {
+				t.Fatal(err)
+			}
+			if len(acc.Metrics) != 2 {
+				t.Fatalf("expected: %d, got: %d", 2, len(acc.Metrics))
+			}
+
+			for i, m := range acc.Metrics {
+				reading := time.Date(2021, 12, 28, 1+i, 0, 0, 0, d.location)
+				original, ok := m.Fields["original_time"]
+				if !tt.useGatherTime {
+					if !m.Time.Equal(reading) || ok {
+						t.Fatalf("expected: %v, got: %v %v", reading, m.Time, m.Fields)
+					}
+					continue
+				}
+
+				if !m.Time.Equal(gatherTime) {
+					t.Fatalf("expected: %v, got: %v", gatherTime, m.Time)
+				}
+				if original != reading.Unix() {
+					t.Fatalf("expected: %v, got: %v", reading.Unix(), original)
+				}
+				// Readings of the same series stay distinct points.
+				if want := strconv.FormatInt(reading.Unix(), 10); m.Tags["original_time"] != want {
+					t.Fatalf("expected: %v, got: %v", want, m.Tags["original_time"])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package datadis
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+)
+
+// lastReading returns the timestamp of the newest reading emitted for cups.
+func (d *Datadis) lastReading(cups string) (time.Time, bool) {
+	d.lastReadingsLock.Lock()
+	defer d.lastReadingsLock.Unlock()
+
+	last, ok := d.lastReadings[cups]
+	return last, ok
+}
+
+// incrementalStart returns the day to request the readings of supply from,
+// the one of its newest reading moved back by overlap.
+func (d *Datadis) incrementalStart(supply Supply) (time.Time, bool) {
+	if !d.Incremental {
+		return time.Time{}, false
+	}
+
+	last, ok := d.lastReading(supply.Cups)
+	if !ok {
+		return time.Time{}, false
+	}
+	last = last.Add(time.Duration(-d.Overlap)).In(d.location)
+	return time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.UTC), true
+}
+
+// recordReadings remembers the newest reading of every supply, persisting
+// them to incremental_state_file.
+func (d *Datadis) recordReadings(newest map[string]time.Time) {
+	if len(newest) == 0 {
+		return
+	}
+
+	d.lastReadingsLock.Lock()
+	if d.lastReadings == nil {
+		d.lastReadings = map[string]time.Time{}
+	}
+	for cups, timestamp := range newest {
+		if timestamp.After(d.lastReadings[cups]) {
+			d.lastReadings[cups] = timestamp
+		}
+	}
+	data, err := json.Marshal(d.lastReadings)
+	d.lastReadingsLock.Unlock()
+
+	if d.IncrementalStateFile == "" {
+		return
+	}
+	if err == nil {
+		err = os.WriteFile(d.IncrementalStateFile, data, 0600)
+	}
+	if err != nil {
+		d.Log.Warnf("Could not save incremental state%s", logContext("error", err))
+	}
+}
+
+// loadReadings restores the newest readings persisted in
+// incremental_state_file, if any.
+func (d *Datadis) loadReadings() error {
+	data, err := os.ReadFile(d.IncrementalStateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var readings map[string]time.Time
+	err = json.Unmarshal(data, &readings)
+	if err != nil {
+		return err
+	}
+
+	d.lastReadingsLock.Lock()
+	d.lastReadings = readings
+	d.lastReadingsLock.Unlock()
+	return nil
+}
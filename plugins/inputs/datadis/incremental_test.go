@@ -0,0 +1,147 @@
+package datadis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestIncremental(t *testing.T) {
+	var requested []string
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		requested = append(requested, query.Get("startDate"))
+		fmt.Fprint(rw, `[ {
+			"cups" : "1234",
+			"date" : "2021/12/28",
+			"time" : "23:00",
+			"consumptionKWh" : 0.121,
+			"obtainMethod" : "Real"
+		  }, {
+			"cups" : "1234",
+			"date" : "2021/12/28",
+			"time" : "24:00",
+			"consumptionKWh" : 0.103,
+			"obtainMethod" : "Real"
+		  } ]`)
+	}))
+	defer ts.Close()
+
+	newPlugin := func(stateFile string) *Datadis {
+		return &Datadis{
+			BaseURL:              ts.URL,
+			httpClient:           ts.Client(),
+			StartDate:            "2021/12/01",
+			EndDate:              "2021/12/31",
+			Incremental:          true,
+			IncrementalStateFile: stateFile,
+			location:             time.UTC,
+			Log:                  testutil.Logger{},
+		}
+	}
+
+	gather := func(d *Datadis) int {
+		requested = nil
+		data, err := fetchConsumption(context.Background(), d, Supply{Cups: "1234"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		acc := testutil.Accumulator{}
+		if err := d.aggregateMetrcs(&acc, data); err != nil {
+			t.Fatal(err)
+		}
+		return len(acc.Metrics)
+	}
+
+	stateFile := filepath.Join(t.TempDir(), "state")
+	d := newPlugin(stateFile)
+
+	if got := gather(d); got != 2 {
+		t.Fatalf("expected: %d, got: %d", 2, got)
+	}
+	if requested[0] != "2021/12/01" {
+		t.Fatalf("expected: %v, got: %v", "2021/12/01", requested[0])
+	}
+
+	if got := gather(d); got != 0 {
+		t.Fatalf("expected: %d, got: %d", 0, got)
+	}
+	want := []string{"2021/12/29"}
+	if fmt.Sprint(requested) != fmt.Sprint(want) {
+		t.Fatalf("expected: %v, got: %v", want, requested)
+	}
+
+	t.Run("Should resume from the state file", func(t *testing.T) {
+		d := newPlugin(stateFile)
+		if err := d.loadReadings(); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := gather(d); got != 0 {
+			t.Fatalf("expected: %d, got: %d", 0, got)
+		}
+		if fmt.Sprint(requested) != fmt.Sprint(want) {
+			t.Fatalf("expected: %v, got: %v", want, requested)
+		}
+	})
+}
+
+func TestOverlap(t *testing.T) {
+	tests := []struct {
+		name    string
+		overlap time.Duration
+		want    string
+	}{
+		{"Should start at the newest reading without overlap", 0, "2021/12/28"},
+		{"Should start within the same day", 6 * time.Hour, "2021/12/28"},
+		{"Should extend the start backward", 48 * time.Hour, "2021/12/26"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Datadis{
+				Incremental:  true,
+				Overlap:      config.Duration(tt.overlap),
+				location:     time.UTC,
+				lastReadings: map[string]time.Time{"1234": time.Date(2021, 12, 28, 15, 0, 0, 0, time.UTC)},
+			}
+
+			start, ok := d.incrementalStart(Supply{Cups: "1234"})
+			if !ok {
+				t.Fatal("expected: a start date, got: none")
+			}
+			if got := start.Format("2006/01/02"); got != tt.want {
+				t.Fatalf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("Should emit the readings within the overlap", func(t *testing.T) {
+		d := Datadis{
+			Incremental:  true,
+			Overlap:      config.Duration(6 * time.Hour),
+			location:     time.UTC,
+			lastReadings: map[string]time.Time{"1234": time.Date(2021, 12, 28, 15, 0, 0, 0, time.UTC)},
+		}
+
+		acc := testutil.Accumulator{}
+		err := d.aggregateMetrcs(&acc, []Consumption{
+			{Cups: "1234", Date: "2021/12/28", Time: "08:00", KWh: 0.1, ObtainMethod: "Real"},
+			{Cups: "1234", Date: "2021/12/28", Time: "12:00", KWh: 0.2, ObtainMethod: "Real"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(acc.Metrics) != 1 || acc.Metrics[0].Fields["kwh"] != 0.2 {
+			t.Fatalf("expected: %v, got: %v", 0.2, acc.Metrics)
+		}
+	})
+}
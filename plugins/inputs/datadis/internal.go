@@ -0,0 +1,50 @@
+package datadis
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/telegraf"
+)
+
+// errorCounter counts the errors reported during a gather.
+type errorCounter struct {
+	telegraf.Accumulator
+
+	mu     sync.Mutex
+	errors int
+}
+
+func (e *errorCounter) AddError(err error) {
+	e.mu.Lock()
+	e.errors++
+	e.mu.Unlock()
+	e.Accumulator.AddError(err)
+}
+
+func (e *errorCounter) count() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.errors
+}
+
+// countFetched adds records to the records fetched from Datadis in this
+// gather, before any aggregation or deduplication.
+func (d *Datadis) countFetched(records int) {
+	atomic.AddInt64(&d.fetched, int64(records))
+}
+
+// addInternalMetrics reports the health of a gather.
+func (d *Datadis) addInternalMetrics(acc telegraf.Accumulator, duration time.Duration, records, errors int) {
+	fields := map[string]interface{}{
+		"gather_duration_ms": float64(duration) / float64(time.Millisecond),
+		"supplies_count":     len(d.Supplies),
+		"records_fetched":    records,
+		"errors_count":       errors,
+	}
+	if expiry := d.currentTokenExpiry(); !expiry.IsZero() {
+		fields["token_expires_in_seconds"] = int64(expiry.Sub(d.clock()) / time.Second)
+	}
+	acc.AddFields(d.measurement("internal"), fields, nil)
+}
@@ -0,0 +1,119 @@
+package datadis
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestInternalMetrics(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nikola-auth/tokens/login":
+			fmt.Fprint(rw, "token")
+		case "/api-private/api/get-consumption-data":
+			if r.URL.Query().Get("cups") == "5678" {
+				rw.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			time.Sleep(time.Millisecond)
+			fmt.Fprint(rw, `[ {
+				"cups" : "1234",
+				"date" : "2021/12/28",
+				"time" : "01:00",
+				"consumptionKWh" : 0.121,
+				"obtainMethod" : "Real"
+			  } ]`)
+		}
+	}))
+	defer ts.Close()
+
+	d := Datadis{
+		BaseURL:               ts.URL,
+		Username:              "user",
+		Password:              "pass",
+		Timezone:              Timezone,
+		StartDate:             "2021/12/28",
+		EndDate:               "2021/12/28",
+		Supplies:              []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}, {Cups: "5678", PointType: 5, DistributorCode: "2"}},
+		GatherInternalMetrics: true,
+		Log:                   testutil.Logger{},
+	}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	acc := testutil.Accumulator{}
+	if err := d.Gather(&acc); err != nil {
+		t.Fatal(err)
+	}
+
+	m, ok := acc.Get("datadis_internal")
+	if !ok {
+		t.Fatal("missing datadis_internal metric")
+	}
+	if duration := m.Fields["gather_duration_ms"].(float64); duration <= 0 {
+		t.Fatalf("expected: nonzero duration, got: %v", duration)
+	}
+
+	want := map[string]interface{}{"supplies_count": 2, "records_fetched": 1, "errors_count": 1}
+	for field, value := range want {
+		if m.Fields[field] != value {
+			t.Fatalf("expected %v: %v, got: %v", field, value, m.Fields[field])
+		}
+	}
+
+	t.Run("Should count the records fetched before aggregating them", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/nikola-auth/tokens/login" {
+				fmt.Fprint(rw, "token")
+				return
+			}
+			fmt.Fprint(rw, `[ {
+				"cups" : "1234",
+				"date" : "2021/11",
+				"consumptionKWh" : 231.5,
+				"obtainMethod" : "Real"
+			  }, {
+				"cups" : "1234",
+				"date" : "2021/12",
+				"consumptionKWh" : 254.25,
+				"obtainMethod" : "Real"
+			  } ]`)
+		}))
+		defer ts.Close()
+
+		d := Datadis{
+			BaseURL:               ts.URL,
+			Username:              "user",
+			Password:              "pass",
+			Timezone:              Timezone,
+			StartDate:             "2021/11/01",
+			EndDate:               "2021/12/31",
+			Aggregation:           aggregationMonthly,
+			Supplies:              []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}},
+			GatherInternalMetrics: true,
+			Log:                   testutil.Logger{},
+		}
+		if err := d.Init(); err != nil {
+			t.Fatal(err)
+		}
+
+		acc := testutil.Accumulator{}
+		if err := d.Gather(&acc); err != nil {
+			t.Fatal(err)
+		}
+
+		m, ok := acc.Get("datadis_internal")
+		if !ok {
+			t.Fatal("missing datadis_internal metric")
+		}
+		if got := m.Fields["records_fetched"]; got != 2 {
+			t.Fatalf("expected: %v, got: %v", 2, got)
+		}
+	})
+}
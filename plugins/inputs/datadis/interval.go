@@ -0,0 +1,69 @@
+package datadis
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+)
+
+type gatherState struct {
+	LastGather time.Time `json:"last_gather"`
+}
+
+// gatheredRecently reports whether the last gather is more recent than
+// min_gather_interval, so Datadis isn't requested again yet.
+func (d *Datadis) gatheredRecently(now time.Time) bool {
+	if d.MinGatherInterval <= 0 || d.lastGather.IsZero() {
+		return false
+	}
+
+	next := d.lastGather.Add(time.Duration(d.MinGatherInterval))
+	if !now.Before(next) {
+		return false
+	}
+
+	d.intervalWarning.Do(func() {
+		d.Log.Warnf("Gathering more often than min_gather_interval, skipping the gathers%s",
+			logContext("interval", time.Duration(d.MinGatherInterval), "until", next.Format(time.RFC3339)))
+	})
+	return true
+}
+
+// recordGather remembers the time of the gather starting at now,
+// persisting it to gather_state_file.
+func (d *Datadis) recordGather(now time.Time) {
+	d.lastGather = now
+	if d.GatherStateFile == "" {
+		return
+	}
+
+	data, err := json.Marshal(gatherState{LastGather: now})
+	if err == nil {
+		err = os.WriteFile(d.GatherStateFile, data, 0600)
+	}
+	if err != nil {
+		d.Log.Warnf("Could not save gather state%s", logContext("error", err))
+	}
+}
+
+// loadLastGather restores the time of the last gather persisted in
+// gather_state_file, if any.
+func (d *Datadis) loadLastGather() error {
+	data, err := os.ReadFile(d.GatherStateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var state gatherState
+	err = json.Unmarshal(data, &state)
+	if err != nil {
+		return err
+	}
+
+	d.lastGather = state.LastGather
+	return nil
+}
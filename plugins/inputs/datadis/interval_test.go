@@ -0,0 +1,169 @@
+package datadis
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestMinGatherInterval(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/nikola-auth/tokens/login" {
+			fmt.Fprint(rw, "token")
+			return
+		}
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprint(rw, "[]")
+	}))
+	defer ts.Close()
+
+	now := time.Date(2021, 12, 29, 10, 0, 0, 0, time.UTC)
+	logger := &recordingLogger{}
+	d := Datadis{
+		BaseURL:           ts.URL,
+		Username:          "user",
+		Password:          "pass",
+		Timezone:          Timezone,
+		StartDate:         "2021/12/28",
+		EndDate:           "2021/12/28",
+		Supplies:          []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}},
+		MinGatherInterval: config.Duration(15 * time.Minute),
+		now:               func() time.Time { return now },
+		Log:               logger,
+	}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	gather := func() {
+		acc := testutil.Accumulator{}
+		if err := d.Gather(&acc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gather()
+	now = now.Add(10 * time.Second)
+	gather()
+	now = now.Add(10 * time.Second)
+	gather()
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Fatalf("expected: %d, got: %d", 1, got)
+	}
+	if got := strings.Count(logger.output(), "min_gather_interval"); got != 1 {
+		t.Fatalf("expected: %d warning, got: %d", 1, got)
+	}
+
+	t.Run("Should skip the gathers after a failed gather", func(t *testing.T) {
+		var requests int32
+		ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/nikola-auth/tokens/login" {
+				fmt.Fprint(rw, "token")
+				return
+			}
+			if atomic.AddInt32(&requests, 1) == 1 {
+				rw.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			fmt.Fprint(rw, "[]")
+		}))
+		defer ts.Close()
+
+		now := now
+		d := Datadis{
+			BaseURL:           ts.URL,
+			Username:          "user",
+			Password:          "pass",
+			Timezone:          Timezone,
+			StartDate:         "2021/12/28",
+			EndDate:           "2021/12/28",
+			Supplies:          []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}},
+			MinGatherInterval: config.Duration(15 * time.Minute),
+			now:               func() time.Time { return now },
+			Log:               testutil.Logger{},
+		}
+		if err := d.Init(); err != nil {
+			t.Fatal(err)
+		}
+
+		acc := testutil.Accumulator{}
+		if err := d.Gather(&acc); err != nil {
+			t.Fatal(err)
+		}
+		if len(acc.Errors) == 0 {
+			t.Fatal("expected error")
+		}
+		now = now.Add(10 * time.Second)
+		if err := d.Gather(&testutil.Accumulator{}); err != nil {
+			t.Fatal(err)
+		}
+		if got := atomic.LoadInt32(&requests); got != 1 {
+			t.Fatalf("expected: %d, got: %d", 1, got)
+		}
+
+		now = now.Add(15 * time.Minute)
+		acc = testutil.Accumulator{}
+		if err := d.Gather(&acc); err != nil {
+			t.Fatal(err)
+		}
+		if len(acc.Errors) != 0 {
+			t.Fatalf("unexpected errors: %v", acc.Errors)
+		}
+		if got := atomic.LoadInt32(&requests); got != 2 {
+			t.Fatalf("expected: %d, got: %d", 2, got)
+		}
+	})
+
+	t.Run("Should gather again after the interval", func(t *testing.T) {
+		now = now.Add(15 * time.Minute)
+		gather()
+
+		if got := atomic.LoadInt32(&requests); got != 2 {
+			t.Fatalf("expected: %d, got: %d", 2, got)
+		}
+	})
+
+	t.Run("Should keep the last gather across restarts", func(t *testing.T) {
+		state := filepath.Join(t.TempDir(), "gather")
+		restart := func() *Datadis {
+			d := Datadis{
+				BaseURL:           ts.URL,
+				Username:          "user",
+				Password:          "pass",
+				Timezone:          Timezone,
+				StartDate:         "2021/12/28",
+				EndDate:           "2021/12/28",
+				Supplies:          []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}},
+				MinGatherInterval: config.Duration(15 * time.Minute),
+				GatherStateFile:   state,
+				now:               func() time.Time { return now },
+				Log:               testutil.Logger{},
+			}
+			if err := d.Init(); err != nil {
+				t.Fatal(err)
+			}
+			return &d
+		}
+
+		before := atomic.LoadInt32(&requests)
+		for i := 0; i < 2; i++ {
+			if err := restart().Gather(&testutil.Accumulator{}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if got := atomic.LoadInt32(&requests) - before; got != 1 {
+			t.Fatalf("expected: %d, got: %d", 1, got)
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package datadis
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+)
+
+// sleepJitter waits a random time up to bound, or until ctx is done.
+func sleepJitter(ctx context.Context, bound config.Duration) error {
+	if bound <= 0 {
+		return nil
+	}
+
+	wait := time.Duration(rand.Int63n(int64(bound)))
+	select {
+	case <-time.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
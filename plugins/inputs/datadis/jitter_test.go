@@ -0,0 +1,83 @@
+package datadis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestJitter(t *testing.T) {
+	const bound = 50 * time.Millisecond
+	// slack absorbs the time of the request itself.
+	const slack = 50 * time.Millisecond
+
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nikola-auth/tokens/login":
+			fmt.Fprint(rw, "token")
+		default:
+			fmt.Fprint(rw, "[]")
+		}
+	}))
+	defer ts.Close()
+
+	t.Run("Should delay requests within the jitter", func(t *testing.T) {
+		d := Datadis{
+			BaseURL:      ts.URL,
+			httpClient:   ts.Client(),
+			StartDate:    "2021/12/28",
+			EndDate:      "2021/12/28",
+			GatherJitter: config.Duration(bound),
+			Log:          testutil.Logger{},
+		}
+
+		for i := 0; i < 5; i++ {
+			start := time.Now()
+			if _, err := fetchConsumption(context.Background(), &d, Supply{}); err != nil {
+				t.Fatal(err)
+			}
+			if elapsed := time.Since(start); elapsed > bound+slack {
+				t.Fatalf("expected: at most %v, got: %v", bound+slack, elapsed)
+			}
+		}
+	})
+	t.Run("Should delay the first login within the jitter", func(t *testing.T) {
+		d := Datadis{
+			BaseURL:       ts.URL,
+			Username:      "user",
+			Password:      "pass",
+			Timezone:      Timezone,
+			Supplies:      []Supply{},
+			StartupJitter: config.Duration(bound),
+			Log:           testutil.Logger{},
+		}
+		if err := d.Init(); err != nil {
+			t.Fatal(err)
+		}
+
+		start := time.Now()
+		if err := d.Gather(&testutil.Accumulator{}); err != nil {
+			t.Fatal(err)
+		}
+		if elapsed := time.Since(start); elapsed > bound+slack {
+			t.Fatalf("expected: at most %v, got: %v", bound+slack, elapsed)
+		}
+		if !d.startupJittered {
+			t.Fatal("expected the startup jitter to apply once")
+		}
+	})
+	t.Run("Should stop waiting when cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := sleepJitter(ctx, config.Duration(time.Hour)); err != context.Canceled {
+			t.Fatalf("expected: %v, got: %v", context.Canceled, err)
+		}
+	})
+}
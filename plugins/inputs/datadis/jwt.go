@@ -0,0 +1,61 @@
+package datadis
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// tokenExpiry reads the exp claim of a JWT token. The signature is not
+// verified, the expiry is only used to schedule refreshes.
+func tokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		Exp *float64 `json:"exp"`
+	}
+	err = json.Unmarshal(payload, &claims)
+	if err != nil || claims.Exp == nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(*claims.Exp), 0), true
+}
+
+// setToken replaces the token, along with its expiry when it can be read.
+func (d *Datadis) setToken(token string) {
+	expiry, _ := tokenExpiry(token)
+
+	d.tokenLock.Lock()
+	d.token = token
+	d.tokenExpiry = expiry
+	d.tokenLock.Unlock()
+}
+
+// tokenExpiring reports whether the token expires within
+// token_refresh_margin.
+func (d *Datadis) tokenExpiring() bool {
+	expiry := d.currentTokenExpiry()
+	return !expiry.IsZero() && expiry.Sub(d.clock()) < time.Duration(d.TokenRefreshMargin)
+}
+
+// tokenValid reports whether the token has not expired yet.
+func (d *Datadis) tokenValid() bool {
+	return d.currentTokenExpiry().After(d.clock())
+}
+
+// currentTokenExpiry returns when the token expires, or the zero time when
+// unknown.
+func (d *Datadis) currentTokenExpiry() time.Time {
+	d.tokenLock.Lock()
+	defer d.tokenLock.Unlock()
+	return d.tokenExpiry
+}
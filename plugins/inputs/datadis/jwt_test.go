@@ -0,0 +1,178 @@
+package datadis
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+)
+
+// sampleJWT returns an unsigned token with the given claims.
+func sampleJWT(claims string) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	return encode([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + encode([]byte(claims)) + ".signature"
+}
+
+func TestTokenExpiry(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		want   time.Time
+		wantOk bool
+	}{
+		{"Should parse the exp claim", sampleJWT(`{"sub":"12345678Z","exp":1640995200}`), time.Unix(1640995200, 0), true},
+		{"Should ignore tokens without exp", sampleJWT(`{"sub":"12345678Z"}`), time.Time{}, false},
+		{"Should ignore opaque tokens", "token", time.Time{}, false},
+		{"Should ignore malformed payloads", "a.b!.c", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tokenExpiry(tt.token)
+			if ok != tt.wantOk || !got.Equal(tt.want) {
+				t.Fatalf("expected: %v %v, got: %v %v", tt.want, tt.wantOk, got, ok)
+			}
+		})
+	}
+
+	t.Run("Should report the remaining validity", func(t *testing.T) {
+		d := Datadis{}
+		d.setToken(sampleJWT(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(time.Hour).Unix())))
+
+		acc := testutil.Accumulator{}
+		d.addInternalMetrics(&acc, time.Second, 0, 0)
+
+		got, ok := acc.Metrics[0].Fields["token_expires_in_seconds"].(int64)
+		if !ok || got < 3590 || got > 3600 {
+			t.Fatalf("expected: %v, got: %v", 3600, acc.Metrics[0].Fields["token_expires_in_seconds"])
+		}
+	})
+}
+
+func TestTokenRefreshMargin(t *testing.T) {
+	var paths []string
+	fresh := sampleJWT(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(24*time.Hour).Unix()))
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/nikola-auth/tokens/login" {
+			fmt.Fprint(rw, fresh)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+fresh {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(rw, "[]")
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name   string
+		expiry time.Duration
+		want   []string
+	}{
+		{
+			"Should refresh a token about to expire",
+			time.Minute,
+			[]string{"/nikola-auth/tokens/login", "/api-private/api/get-consumption-data"},
+		},
+		{
+			"Should keep a valid token",
+			time.Hour,
+			[]string{"/api-private/api/get-consumption-data", "/nikola-auth/tokens/login", "/api-private/api/get-consumption-data"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths = nil
+			d := Datadis{
+				BaseURL:            ts.URL,
+				Username:           "user",
+				Password:           "pass",
+				Timezone:           Timezone,
+				StartDate:          "2021/12/28",
+				EndDate:            "2021/12/28",
+				Supplies:           []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}},
+				TokenRefreshMargin: config.Duration(5 * time.Minute),
+				Log:                testutil.Logger{},
+			}
+			if err := d.Init(); err != nil {
+				t.Fatal(err)
+			}
+			d.setToken(sampleJWT(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(tt.expiry).Unix())))
+
+			acc := testutil.Accumulator{}
+			if err := d.Gather(&acc); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(paths, tt.want) {
+				t.Fatalf("expected: %v, got: %v", tt.want, paths)
+			}
+		})
+	}
+}
+
+func TestFailOnAuthError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/nikola-auth/tokens/login" {
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(rw, `[ {
+			"cups" : "1234",
+			"date" : "2021/12/28",
+			"time" : "01:00",
+			"consumptionKWh" : 0.121,
+			"obtainMethod" : "Real"
+		  } ]`)
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name            string
+		failOnAuthError bool
+		expiry          time.Duration
+		wantErr         bool
+	}{
+		{"Should abort the gather", true, time.Minute, true},
+		{"Should gather with the current token", false, time.Minute, false},
+		{"Should abort the gather with an expired token", false, -time.Minute, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Datadis{
+				BaseURL:            ts.URL,
+				Username:           "user",
+				Password:           "pass",
+				Timezone:           Timezone,
+				StartDate:          "2021/12/28",
+				EndDate:            "2021/12/28",
+				Supplies:           []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}},
+				TokenRefreshMargin: config.Duration(5 * time.Minute),
+				FailOnAuthError:    tt.failOnAuthError,
+				Log:                testutil.Logger{},
+			}
+			if err := d.Init(); err != nil {
+				t.Fatal(err)
+			}
+			d.setToken(sampleJWT(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(tt.expiry).Unix())))
+
+			acc := testutil.Accumulator{}
+			err := d.Gather(&acc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tt.wantErr, err)
+			}
+			if !tt.wantErr && len(acc.Metrics) != 1 {
+				t.Fatalf("expected: %d, got: %d", 1, len(acc.Metrics))
+			}
+		})
+	}
+}
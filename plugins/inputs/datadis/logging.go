@@ -0,0 +1,76 @@
+package datadis
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logContext formats key-value pairs to append to a log message, like
+// ` cups=ES0099999999999999AAAA start=2021/12/28`, so log aggregation can
+// filter on them. Values with spaces or quotes are quoted.
+func logContext(keyvals ...interface{}) string {
+	var b strings.Builder
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		value := fmt.Sprint(keyvals[i+1])
+		if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+			value = strconv.Quote(value)
+		}
+		fmt.Fprintf(&b, " %v=%v", keyvals[i], value)
+	}
+	return b.String()
+}
+
+// logGather logs the supplies and the dates a gather requests.
+func (d *Datadis) logGather() {
+	d.Log.Debugf("Gathering%s", logContext(append([]interface{}{"supplies", len(d.Supplies)}, d.gatherDates()...)...))
+}
+
+// logSummary logs the outcome of a successful gather at info level, as a
+// heartbeat.
+func (d *Datadis) logSummary(records int, duration time.Duration) {
+	keyvals := append([]interface{}{"supplies", len(d.Supplies), "records", records}, d.gatherDates()...)
+	d.Log.Infof("Gathered%s", logContext(append(keyvals, "duration", duration.Round(time.Millisecond))...))
+}
+
+// gatherDates returns the dates a gather requests as key-value pairs for
+// logContext.
+func (d *Datadis) gatherDates() []interface{} {
+	if len(d.Dates) > 0 {
+		return []interface{}{"dates", strings.Join(d.Dates, ",")}
+	}
+
+	start, end, err := d.dateRange()
+	if err != nil {
+		return nil
+	}
+	return []interface{}{"start", start.Format(dayLayout), "end", end.Format(dayLayout)}
+}
+
+// redactedProxy returns http_proxy without its password.
+func (d *Datadis) redactedProxy() string {
+	proxyURL, err := url.Parse(d.HTTPProxy)
+	if err != nil {
+		return ""
+	}
+	return proxyURL.Redacted()
+}
+
+// logRecords logs the readings fetched for every supply, in this gather and
+// in total, to spot a meter returning nothing.
+func (d *Datadis) logRecords(consumptions []Consumption) {
+	records := map[string]int{}
+	for _, consumption := range consumptions {
+		records[consumption.Cups]++
+	}
+
+	if d.recordTotals == nil {
+		d.recordTotals = map[string]int{}
+	}
+	for _, supply := range d.Supplies {
+		d.recordTotals[supply.Cups] += records[supply.Cups]
+		d.Log.Debugf("Fetched consumption%s", logContext("cups", supply.Cups, "records", records[supply.Cups], "total", d.recordTotals[supply.Cups]))
+	}
+}
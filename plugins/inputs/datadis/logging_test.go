@@ -0,0 +1,147 @@
+package datadis
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestLogContext(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyvals []interface{}
+		want    string
+	}{
+		{"Should format key-value pairs", []interface{}{"cups", "1234", "supplies", 2}, " cups=1234 supplies=2"},
+		{"Should quote values with spaces", []interface{}{"error", errors.New("connection refused")}, ` error="connection refused"`},
+		{"Should quote empty values", []interface{}{"distributor_code", ""}, ` distributor_code=""`},
+		{"Should format durations", []interface{}{"wait", 2 * time.Second}, " wait=2s"},
+		{"Should ignore a key without value", []interface{}{"cups"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := logContext(tt.keyvals...); got != tt.want {
+				t.Fatalf("expected: %q, got: %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGatherLogContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nikola-auth/tokens/login":
+			fmt.Fprint(rw, "token")
+		case "/api-private/api/get-supplies":
+			fmt.Fprint(rw, `[{"cups": "1234", "distributorCode": "2", "pointType": 5}]`)
+		default:
+			fmt.Fprint(rw, "[]")
+		}
+	}))
+	defer ts.Close()
+
+	log := &recordingLogger{}
+	d := Datadis{
+		BaseURL:   ts.URL,
+		Username:  "user",
+		Password:  "pass",
+		Timezone:  Timezone,
+		StartDate: "2021/12/28",
+		EndDate:   "2021/12/29",
+		Log:       log,
+	}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	acc := testutil.Accumulator{}
+	if err := d.Gather(&acc); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"D! Discovered supplies supplies=1",
+		"D! Gathering supplies=1 start=2021/12/28 end=2021/12/29",
+	} {
+		if !strings.Contains(log.output(), want) {
+			t.Fatalf("expected: %q, got: %v", want, log.output())
+		}
+	}
+}
+
+func TestLogRecords(t *testing.T) {
+	log := &recordingLogger{}
+	d := Datadis{
+		Supplies: []Supply{{Cups: "1"}, {Cups: "2"}},
+		Log:      log,
+	}
+
+	readings := []Consumption{{Cups: "1", Time: "01:00"}, {Cups: "1", Time: "02:00"}}
+	d.logRecords(readings)
+	d.logRecords(readings[:1])
+
+	for _, want := range []string{
+		"D! Fetched consumption cups=1 records=2 total=2",
+		"D! Fetched consumption cups=2 records=0 total=0",
+		"D! Fetched consumption cups=1 records=1 total=3",
+	} {
+		if !strings.Contains(log.output(), want) {
+			t.Fatalf("expected: %q, got: %v", want, log.output())
+		}
+	}
+}
+
+func TestGatherSummary(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/nikola-auth/tokens/login" {
+			fmt.Fprint(rw, "token")
+			return
+		}
+		fmt.Fprint(rw, `[ {
+			"cups" : "1234",
+			"date" : "2021/12/28",
+			"time" : "01:00",
+			"consumptionKWh" : 0.121,
+			"obtainMethod" : "Real"
+		  }, {
+			"cups" : "1234",
+			"date" : "2021/12/28",
+			"time" : "02:00",
+			"consumptionKWh" : 0.103,
+			"obtainMethod" : "Real"
+		  } ]`)
+	}))
+	defer ts.Close()
+
+	log := &recordingLogger{}
+	d := Datadis{
+		BaseURL:   ts.URL,
+		Username:  "user",
+		Password:  "pass",
+		Timezone:  Timezone,
+		StartDate: "2021/12/28",
+		EndDate:   "2021/12/28",
+		Supplies:  []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}},
+		Log:       log,
+	}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	acc := testutil.Accumulator{}
+	if err := d.Gather(&acc); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "I! Gathered supplies=1 records=2 start=2021/12/28 end=2021/12/28 duration="
+	if !strings.Contains(log.output(), want) {
+		t.Fatalf("expected: %q, got: %v", want, log.output())
+	}
+}
@@ -0,0 +1,58 @@
+package datadis
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+)
+
+// errMaintenance is returned for the 503 answered during the maintenance
+// windows of Datadis.
+var errMaintenance = errors.New("datadis is under maintenance")
+
+// dataEndpoint reports whether req asks for the readings of a supply. Only
+// their 503 is taken for maintenance, the login and the supplies answer it
+// when merely overloaded.
+func (d *Datadis) dataEndpoint(req *http.Request) bool {
+	return req.URL.Path != d.loginURL().Path && req.URL.Path != d.endpoint("get-supplies").Path
+}
+
+// maintenanceFilter keeps the maintenance errors out of the accumulator,
+// noting that one happened instead.
+type maintenanceFilter struct {
+	telegraf.Accumulator
+
+	mu  sync.Mutex
+	hit bool
+}
+
+func (m *maintenanceFilter) AddError(err error) {
+	if errors.Is(err, errMaintenance) {
+		m.mu.Lock()
+		m.hit = true
+		m.mu.Unlock()
+		return
+	}
+	m.Accumulator.AddError(err)
+}
+
+func (m *maintenanceFilter) underMaintenance() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.hit
+}
+
+// enterMaintenance warns about a gather skipped by maintenance and delays
+// the next ones by maintenance_backoff.
+func (d *Datadis) enterMaintenance() {
+	if d.MaintenanceBackoff <= 0 {
+		d.Log.Warn("Datadis is under maintenance, skipping the gather")
+		return
+	}
+
+	d.maintenanceUntil = d.clock().Add(time.Duration(d.MaintenanceBackoff))
+	d.Log.Warnf("Datadis is under maintenance, skipping the gathers%s", logContext("until", d.maintenanceUntil.Format(time.RFC3339)))
+}
@@ -0,0 +1,122 @@
+package datadis
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestMaintenance(t *testing.T) {
+	var (
+		requests int
+		mu       sync.Mutex
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/nikola-auth/tokens/login" {
+			fmt.Fprint(rw, "token")
+			return
+		}
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	now := time.Date(2021, 12, 29, 10, 0, 0, 0, time.UTC)
+	log := &recordingLogger{}
+	d := Datadis{
+		BaseURL:            ts.URL,
+		Username:           "user",
+		Password:           "pass",
+		Timezone:           Timezone,
+		StartDate:          "2021/12/28",
+		EndDate:            "2021/12/28",
+		Supplies:           []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}, {Cups: "5678", PointType: 5, DistributorCode: "2"}},
+		MaintenanceBackoff: config.Duration(time.Hour),
+		now:                func() time.Time { return now },
+		Log:                log,
+	}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	acc := testutil.Accumulator{}
+	if err := d.Gather(&acc); err != nil {
+		t.Fatalf("expected: nil, got: %v", err)
+	}
+	if len(acc.Errors) != 0 {
+		t.Fatalf("expected: no errors, got: %v", acc.Errors)
+	}
+	if got := strings.Count(log.output(), "W! Datadis is under maintenance"); got != 1 {
+		t.Fatalf("expected: a single warning, got: %v", log.output())
+	}
+	if requests != 2 {
+		t.Fatalf("expected: %d, got: %d", 2, requests)
+	}
+
+	t.Run("Should skip the gathers during the backoff", func(t *testing.T) {
+		if err := d.Gather(&acc); err != nil {
+			t.Fatalf("expected: nil, got: %v", err)
+		}
+		if requests != 2 {
+			t.Fatalf("expected: %d, got: %d", 2, requests)
+		}
+	})
+	t.Run("Should gather again after the backoff", func(t *testing.T) {
+		now = now.Add(time.Hour)
+		if err := d.Gather(&acc); err != nil {
+			t.Fatalf("expected: nil, got: %v", err)
+		}
+		if requests != 4 {
+			t.Fatalf("expected: %d, got: %d", 4, requests)
+		}
+	})
+}
+
+func TestLoginUnavailable(t *testing.T) {
+	var logins int
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/nikola-auth/tokens/login" {
+			logins++
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(rw, "[]")
+	}))
+	defer ts.Close()
+
+	d := Datadis{
+		BaseURL:            ts.URL,
+		Username:           "user",
+		Password:           "pass",
+		Timezone:           Timezone,
+		StartDate:          "2021/12/28",
+		EndDate:            "2021/12/28",
+		Supplies:           []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}},
+		MaintenanceBackoff: config.Duration(time.Hour),
+		Log:                testutil.Logger{},
+	}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := d.Gather(&testutil.Accumulator{}); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+	if !d.maintenanceUntil.IsZero() {
+		t.Fatalf("expected: no maintenance, got: until %v", d.maintenanceUntil)
+	}
+	if logins != 2 {
+		t.Fatalf("expected: %d, got: %d", 2, logins)
+	}
+}
@@ -0,0 +1,92 @@
+package datadis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/telegraf"
+)
+
+type maximeterKey struct {
+	cups   string
+	month  string
+	period string
+}
+
+// contractTariffs returns the access tariff of every supply with a known
+// contract.
+func contractTariffs(contracts []ContractDetail) map[string]string {
+	tariffs := map[string]string{}
+	for _, contract := range contracts {
+		tariffs[normalizeCups(contract.Cups)] = contract.AccessFare
+	}
+	return tariffs
+}
+
+// maximeterPeriod returns the billing period, P1 to P6, of a max power
+// reading. 3.0TD supplies number their six periods, while 2.0TD supplies
+// may report the time band, where punta and llano share the first power
+// period.
+func maximeterPeriod(period, tariff string) string {
+	label := strings.ToUpper(strings.TrimSpace(period))
+	if n, err := strconv.Atoi(strings.TrimPrefix(label, "P")); err == nil && n >= 1 && n <= 6 {
+		return fmt.Sprintf("P%d", n)
+	}
+	if strings.HasPrefix(tariff, "3.0") {
+		return label
+	}
+
+	switch label {
+	case "PUNTA", "LLANO":
+		return "P1"
+	case "VALLE":
+		return "P2"
+	}
+	return label
+}
+
+// addMaximeter adds the highest power of every billing period per month.
+func (d *Datadis) addMaximeter(acc telegraf.Accumulator, maxPower []MaxPower, tariffs map[string]string) {
+	var (
+		maximeter = map[maximeterKey]float64{}
+		order     []maximeterKey
+	)
+
+	for _, power := range maxPower {
+		timestamp, err := time.Parse(dayLayout, power.Date)
+		if err != nil {
+			acc.AddError(err)
+			continue
+		}
+
+		cups := normalizeCups(power.Cups)
+		key := maximeterKey{
+			cups:   cups,
+			month:  timestamp.Format(monthLayout),
+			period: maximeterPeriod(power.Period, tariffs[cups]),
+		}
+		value, ok := maximeter[key]
+		if !ok {
+			order = append(order, key)
+		}
+		if !ok || power.MaxPower > value {
+			maximeter[key] = power.MaxPower
+		}
+	}
+
+	for _, key := range order {
+		timestamp, err := time.ParseInLocation(monthLayout, key.month, d.location)
+		if err != nil {
+			acc.AddError(err)
+			continue
+		}
+
+		tags := map[string]string{"cups": key.cups}
+		if key.period != "" {
+			tags["period"] = key.period
+		}
+		acc.AddFields(d.measurement("maximeter"), map[string]interface{}{"kw": maximeter[key]}, tags, timestamp)
+	}
+}
@@ -0,0 +1,93 @@
+package datadis
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestMaximeter(t *testing.T) {
+	payload := `[ {
+		"cups" : "1234",
+		"date" : "2021/11/15",
+		"time" : "20:00",
+		"maxPower" : 3.254,
+		"period" : "PUNTA"
+	  }, {
+		"cups" : "1234",
+		"date" : "2021/11/20",
+		"time" : "10:00",
+		"maxPower" : 3.512,
+		"period" : "LLANO"
+	  }, {
+		"cups" : "1234",
+		"date" : "2021/11/21",
+		"time" : "03:00",
+		"maxPower" : 1.120,
+		"period" : "VALLE"
+	  }, {
+		"cups" : "5678",
+		"date" : "2021/11/03",
+		"time" : "12:00",
+		"maxPower" : 24.5,
+		"period" : "3"
+	  }, {
+		"cups" : "5678",
+		"date" : "2021/11/04",
+		"time" : "23:00",
+		"maxPower" : 18.1,
+		"period" : "6"
+	  } ]`
+
+	var maxPower []MaxPower
+	if err := json.Unmarshal([]byte(payload), &maxPower); err != nil {
+		t.Fatal(err)
+	}
+
+	d := Datadis{location: time.UTC}
+	acc := testutil.Accumulator{}
+	d.addMaximeter(&acc, maxPower, map[string]string{"1234": "2.0TD", "5678": "3.0TD"})
+
+	if len(acc.Metrics) != 4 {
+		t.Fatalf("expected: %d, got: %d", 4, len(acc.Metrics))
+	}
+
+	tests := []struct {
+		cups   string
+		period string
+		kw     float64
+	}{
+		{"1234", "P1", 3.512},
+		{"1234", "P2", 1.120},
+		{"5678", "P3", 24.5},
+		{"5678", "P6", 18.1},
+	}
+	for _, tt := range tests {
+		acc.AssertContainsTaggedFields(t, "datadis_maximeter",
+			map[string]interface{}{"kw": tt.kw},
+			map[string]string{"cups": tt.cups, "period": tt.period})
+	}
+
+	m, _ := acc.Get("datadis_maximeter")
+	want := time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+	if !m.Time.Equal(want) {
+		t.Fatalf("expected: %v, got: %v", want, m.Time)
+	}
+
+	t.Run("Should normalize the CUPS", func(t *testing.T) {
+		acc := testutil.Accumulator{}
+		d.addMaximeter(&acc, []MaxPower{
+			{Cups: " es0001 ", Date: "2021/11/03", Time: "12:00", MaxPower: 24.5, Period: "PUNTA"},
+			{Cups: "ES0001", Date: "2021/11/04", Time: "12:00", MaxPower: 20.1, Period: "PUNTA"},
+		}, contractTariffs([]ContractDetail{{Cups: "es0001", AccessFare: "3.0TD"}}))
+
+		if len(acc.Metrics) != 1 {
+			t.Fatalf("expected: %d, got: %d", 1, len(acc.Metrics))
+		}
+		acc.AssertContainsTaggedFields(t, "datadis_maximeter",
+			map[string]interface{}{"kw": 24.5},
+			map[string]string{"cups": "ES0001", "period": "PUNTA"})
+	})
+}
@@ -0,0 +1,90 @@
+package datadis
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/influxdata/telegraf"
+)
+
+// MaxPower is the maximum power demanded by a supply within a month.
+type MaxPower struct {
+	Cups     string  `json:"cups"`
+	Date     string  `json:"date"`
+	Time     string  `json:"time"`
+	MaxPower float64 `json:"maxPower"`
+	Period   string  `json:"period"`
+}
+
+func fetchMaxPower(ctx context.Context, d *Datadis, supply Supply) ([]MaxPower, error) {
+	maxPowerURL := d.endpoint("get-max-power")
+
+	params := url.Values{
+		"cups":            {supply.Cups},
+		"distributorCode": {supply.DistributorCode},
+	}
+
+	start, end, err := d.dateRange()
+	if err != nil {
+		return nil, err
+	}
+	setDateRange(params, start, end, monthLayout)
+
+	d.addAuthorizedNif(params)
+	maxPowerURL.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", maxPowerURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	var data []MaxPower
+	if resp.StatusCode == 200 {
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, statusError("max power", resp)
+	}
+
+	return data, nil
+}
+
+// goMaxPower schedules the max power of every supply in group, collected
+// into maxPower.
+func (d *Datadis) goMaxPower(ctx context.Context, group *supplyGroup, maxPower *[]MaxPower) {
+	group.goEach(ctx, func(ctx context.Context, supply Supply) error {
+		data, err := fetchMaxPower(ctx, d, supply)
+
+		group.locked(func() {
+			*maxPower = append(*maxPower, data...)
+		})
+		return err
+	})
+}
+
+func (d *Datadis) addMaxPower(acc telegraf.Accumulator, maxPower []MaxPower) {
+	for _, power := range maxPower {
+		timestamp, err := parseTimestamp(power.Date, power.Time, d.location)
+		if err != nil {
+			acc.AddError(err)
+			continue
+		}
+
+		tags := map[string]string{"cups": normalizeCups(power.Cups)}
+		if power.Period != "" {
+			tags["period"] = power.Period
+		}
+		acc.AddFields(d.measurement("max_power"), map[string]interface{}{"kw": power.MaxPower}, tags, *timestamp)
+	}
+}
@@ -0,0 +1,74 @@
+package datadis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestFetchMaxPower(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api-private/api/get-max-power" {
+			t.Fatalf("unexpected path: %q", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("startDate") != "2021/11" {
+			t.Fatalf("expected: %q, got: %q", "2021/11", query.Get("startDate"))
+		}
+		if query.Get("endDate") != "2021/12" {
+			t.Fatalf("expected: %q, got: %q", "2021/12", query.Get("endDate"))
+		}
+
+		fmt.Fprint(rw, `[ {
+			"cups" : "1234",
+			"date" : "2021/11/15",
+			"time" : "20:00",
+			"maxPower" : 3.254,
+			"period" : "PUNTA"
+		  }, {
+			"cups" : "1234",
+			"date" : "2021/12/02",
+			"time" : "09:15",
+			"maxPower" : 2.801,
+			"period" : "LLANO"
+		  } ]`)
+	}))
+	defer ts.Close()
+
+	d := Datadis{
+		BaseURL:    ts.URL,
+		httpClient: ts.Client(),
+		StartDate:  "2021/11/01",
+		EndDate:    "2021/12/31",
+		location:   time.UTC,
+	}
+
+	got, err := fetchMaxPower(context.Background(), &d, Supply{Cups: "1234"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected: %d, got: %d", 2, len(got))
+	}
+
+	acc := testutil.Accumulator{}
+	d.addMaxPower(&acc, got)
+
+	acc.AssertContainsTaggedFields(t, "datadis_max_power",
+		map[string]interface{}{"kw": 3.254},
+		map[string]string{"cups": "1234", "period": "PUNTA"})
+
+	m, ok := acc.Get("datadis_max_power")
+	if !ok {
+		t.Fatal("missing datadis_max_power metric")
+	}
+	want := time.Date(2021, 11, 15, 20, 0, 0, 0, time.UTC)
+	if !m.Time.Equal(want) {
+		t.Fatalf("expected: %v, got: %v", want, m.Time)
+	}
+}
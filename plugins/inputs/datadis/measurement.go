@@ -0,0 +1,27 @@
+package datadis
+
+// defaultMeasurementPrefix prefixes the measurements when
+// measurement_prefix is unset.
+const defaultMeasurementPrefix = "datadis_"
+
+// legacyMeasurement is the measurement the consumption was emitted to before
+// every endpoint got its own, kept with legacy_measurement.
+const legacyMeasurement = "Datadis"
+
+// measurement returns the measurement of a kind of data, like consumption or
+// max_power, with the configured prefix.
+func (d *Datadis) measurement(name string) string {
+	prefix := d.MeasurementPrefix
+	if prefix == "" {
+		prefix = defaultMeasurementPrefix
+	}
+	return prefix + name
+}
+
+// consumptionMeasurement returns the measurement of the readings.
+func (d *Datadis) consumptionMeasurement() string {
+	if d.LegacyMeasurement {
+		return legacyMeasurement
+	}
+	return d.measurement("consumption")
+}
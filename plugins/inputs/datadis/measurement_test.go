@@ -0,0 +1,117 @@
+package datadis
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestMeasurements(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nikola-auth/tokens/login":
+			fmt.Fprint(rw, "token")
+		case "/api-private/api/get-consumption-data":
+			fmt.Fprint(rw, `[ {
+				"cups" : "1234",
+				"date" : "2021/12/28",
+				"time" : "01:00",
+				"consumptionKWh" : 0.121,
+				"obtainMethod" : "Real"
+			  } ]`)
+		case "/api-private/api/get-max-power":
+			fmt.Fprint(rw, `[ {
+				"cups" : "1234",
+				"date" : "2021/12/28",
+				"time" : "20:00",
+				"maxPower" : 3.254,
+				"period" : "PUNTA"
+			  } ]`)
+		case "/api-private/api/get-contract-detail":
+			fmt.Fprint(rw, `[ {
+				"cups" : "1234",
+				"accessFare" : "2.0TD",
+				"contractedPowerkW" : [ 4.6, 3.45 ],
+				"timeDiscrimination" : "3P",
+				"startDate" : "2021/06/01",
+				"endDate" : ""
+			  } ]`)
+		case "/api-private/api/get-reactive-data":
+			fmt.Fprint(rw, `{
+				"reactiveEnergy" : {
+					"cups" : "1234",
+					"energy" : [ { "date" : "2021/12", "energy_p1" : 1.5 } ]
+				}
+			}`)
+		default:
+			t.Fatalf("unexpected path: %q", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name   string
+		prefix string
+		legacy bool
+		want   []string
+	}{
+		{
+			name: "Should emit every endpoint to its own measurement",
+			want: []string{"datadis_consumption", "datadis_contract", "datadis_max_power", "datadis_reactive"},
+		},
+		{
+			name:   "Should prefix the measurements",
+			prefix: "energy_",
+			want:   []string{"energy_consumption", "energy_contract", "energy_max_power", "energy_reactive"},
+		},
+		{
+			name:   "Should emit the consumption to the legacy measurement",
+			legacy: true,
+			want:   []string{"Datadis", "datadis_contract", "datadis_max_power", "datadis_reactive"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Datadis{
+				BaseURL:              ts.URL,
+				Username:             "user",
+				Password:             "pass",
+				Timezone:             Timezone,
+				StartDate:            "2021/12/28",
+				EndDate:              "2021/12/28",
+				Supplies:             []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}},
+				GatherMaxPower:       true,
+				GatherContractDetail: true,
+				GatherReactive:       true,
+				MeasurementPrefix:    tt.prefix,
+				LegacyMeasurement:    tt.legacy,
+				Log:                  testutil.Logger{},
+			}
+			if err := d.Init(); err != nil {
+				t.Fatal(err)
+			}
+
+			acc := testutil.Accumulator{}
+			if err := d.Gather(&acc); err != nil {
+				t.Fatal(err)
+			}
+			if len(acc.Errors) != 0 {
+				t.Fatalf("expected: no errors, got: %v", acc.Errors)
+			}
+
+			var got []string
+			for _, m := range acc.Metrics {
+				got = append(got, m.Measurement)
+			}
+			sort.Strings(got)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package datadis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/influxdata/telegraf"
+)
+
+// aggregationMonthly requests the monthly totals of the supplies instead of
+// their hourly readings, far fewer points for long histories.
+const aggregationMonthly = "monthly"
+
+// MonthlyConsumption is the energy consumed by a supply within a month.
+type MonthlyConsumption struct {
+	Cups         string    `json:"cups"`
+	Date         string    `json:"date"`
+	KWh          jsonFloat `json:"consumptionKWh"`
+	ObtainMethod string    `json:"obtainMethod"`
+}
+
+func (m *MonthlyConsumption) timestamp(loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation(monthLayout, m.Date, loc)
+}
+
+func fetchMonthlyConsumption(ctx context.Context, d *Datadis, supply Supply) ([]MonthlyConsumption, error) {
+	monthlyURL := d.endpoint("get-consumption-data-monthly")
+
+	params := url.Values{
+		"cups":            {supply.Cups},
+		"distributorCode": {supply.DistributorCode},
+		"pointType":       {fmt.Sprint(supply.PointType)},
+	}
+
+	start, end, err := d.dateRange()
+	if err != nil {
+		return nil, err
+	}
+	setDateRange(params, start, end, monthLayout)
+
+	d.addAuthorizedNif(params)
+	monthlyURL.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", monthlyURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	var data []MonthlyConsumption
+	if resp.StatusCode == 200 {
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		if err != nil {
+			return nil, err
+		}
+		d.countFetched(len(data))
+	} else {
+		return nil, statusError("monthly consumption", resp)
+	}
+
+	return data, nil
+}
+
+// goMonthlyConsumption schedules the monthly consumption of every supply in
+// group, collected into monthly.
+func (d *Datadis) goMonthlyConsumption(ctx context.Context, group *supplyGroup, monthly *[]MonthlyConsumption) {
+	group.goEach(ctx, func(ctx context.Context, supply Supply) error {
+		data, err := fetchMonthlyConsumption(ctx, d, supply)
+
+		group.locked(func() {
+			*monthly = append(*monthly, data...)
+		})
+		return err
+	})
+}
+
+func (d *Datadis) addMonthlyConsumption(acc telegraf.Accumulator, monthly []MonthlyConsumption) {
+	for _, month := range monthly {
+		if !d.keepObtainMethod(month.ObtainMethod) {
+			continue
+		}
+
+		timestamp, err := month.timestamp(d.location)
+		if err != nil {
+			acc.AddError(err)
+			continue
+		}
+
+		tags := map[string]string{"cups": normalizeCups(month.Cups)}
+		if month.ObtainMethod != "" {
+			tags["obtain_method"] = normalizeObtainMethod(month.ObtainMethod)
+		}
+		acc.AddFields(d.measurement("monthly"), map[string]interface{}{"kwh": float64(month.KWh)}, tags, timestamp)
+	}
+}
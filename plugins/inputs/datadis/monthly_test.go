@@ -0,0 +1,110 @@
+package datadis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestFetchMonthlyConsumption(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api-private/api/get-consumption-data-monthly" {
+			t.Fatalf("unexpected path: %q", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("startDate") != "2021/11" || query.Get("endDate") != "2021/12" {
+			t.Fatalf("unexpected date range: %q - %q", query.Get("startDate"), query.Get("endDate"))
+		}
+
+		fmt.Fprint(rw, `[ {
+			"cups" : "1234",
+			"date" : "2021/11",
+			"consumptionKWh" : 231.5,
+			"obtainMethod" : "Real"
+		  }, {
+			"cups" : "1234",
+			"date" : "2021/12",
+			"consumptionKWh" : "254.25",
+			"obtainMethod" : "Estimada"
+		  } ]`)
+	}))
+	defer ts.Close()
+
+	loc, err := time.LoadLocation(Timezone)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := Datadis{
+		BaseURL:    ts.URL,
+		httpClient: ts.Client(),
+		StartDate:  "2021/11/01",
+		EndDate:    "2021/12/31",
+		location:   loc,
+	}
+
+	got, err := fetchMonthlyConsumption(context.Background(), &d, Supply{Cups: "1234"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected: %d, got: %d", 2, len(got))
+	}
+
+	acc := testutil.Accumulator{}
+	d.addMonthlyConsumption(&acc, got)
+
+	acc.AssertContainsTaggedFields(t, "datadis_monthly",
+		map[string]interface{}{"kwh": 231.5},
+		map[string]string{"cups": "1234", "obtain_method": "real"})
+	acc.AssertContainsTaggedFields(t, "datadis_monthly",
+		map[string]interface{}{"kwh": 254.25},
+		map[string]string{"cups": "1234", "obtain_method": "estimated"})
+
+	want := time.Date(2021, 11, 1, 0, 0, 0, 0, loc)
+	if !acc.Metrics[0].Time.Equal(want) {
+		t.Fatalf("expected: %v, got: %v", want, acc.Metrics[0].Time)
+	}
+
+	t.Run("Should gather the monthly totals instead of the readings", func(t *testing.T) {
+		var paths []string
+		ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.URL.Path)
+			if r.URL.Path == "/nikola-auth/tokens/login" {
+				fmt.Fprint(rw, "token")
+				return
+			}
+			fmt.Fprint(rw, "[]")
+		}))
+		defer ts.Close()
+
+		d := Datadis{
+			BaseURL:     ts.URL,
+			Username:    "user",
+			Password:    "pass",
+			Timezone:    Timezone,
+			StartDate:   "2021/11/01",
+			EndDate:     "2021/12/31",
+			Supplies:    []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}},
+			Aggregation: "monthly",
+			Log:         testutil.Logger{},
+		}
+		if err := d.Init(); err != nil {
+			t.Fatal(err)
+		}
+
+		acc := testutil.Accumulator{}
+		if err := d.Gather(&acc); err != nil {
+			t.Fatal(err)
+		}
+		want := fmt.Sprint([]string{"/nikola-auth/tokens/login", "/api-private/api/get-consumption-data-monthly"})
+		if fmt.Sprint(paths) != want {
+			t.Fatalf("expected: %v, got: %v", want, paths)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package datadis
+
+import (
+	"strings"
+
+	"github.com/influxdata/telegraf"
+)
+
+// addPeriodSummary adds the consumption of every supply within the gather
+// window summed by tariff period.
+func (d *Datadis) addPeriodSummary(acc telegraf.Accumulator, metrics []Consumption) {
+	var (
+		totals = map[string]map[string]interface{}{}
+		order  []string
+	)
+
+	for _, consumption := range metrics {
+		if consumption.Date == "" || consumption.Time == "" || !d.keepObtainMethod(consumption.ObtainMethod) {
+			continue
+		}
+
+		timestamp, err := consumption.timestamp(d.location)
+		if err != nil {
+			acc.AddError(err)
+			continue
+		}
+
+		fields, ok := totals[consumption.Cups]
+		if !ok {
+			fields = map[string]interface{}{"kwh_p1": 0.0, "kwh_p2": 0.0, "kwh_p3": 0.0}
+			totals[consumption.Cups] = fields
+			order = append(order, consumption.Cups)
+		}
+		field := "kwh_" + strings.ToLower(d.tariffPeriod(*timestamp))
+		fields[field] = fields[field].(float64) + consumption.KWh
+	}
+
+	for _, cups := range order {
+		acc.AddFields(d.measurement("period_summary"), totals[cups], map[string]string{"cups": cups})
+	}
+}
@@ -0,0 +1,45 @@
+package datadis
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestPeriodSummary(t *testing.T) {
+	metrics := []Consumption{
+		// Tuesday: 11:00 and 20:00 are punta, 09:00 llano, 03:00 valle.
+		{Cups: "1234", Date: "2021/12/28", Time: "11:00", KWh: 0.5, ObtainMethod: "Real"},
+		{Cups: "1234", Date: "2021/12/28", Time: "20:00", KWh: 0.25, ObtainMethod: "Real"},
+		{Cups: "1234", Date: "2021/12/28", Time: "09:00", KWh: 0.125, ObtainMethod: "Real"},
+		{Cups: "1234", Date: "2021/12/28", Time: "03:00", KWh: 0.1, ObtainMethod: "Real"},
+		// Saturday is valle all day.
+		{Cups: "1234", Date: "2022/01/01", Time: "11:00", KWh: 0.2, ObtainMethod: "Real"},
+		{Cups: "5678", Date: "2021/12/28", Time: "11:00", KWh: 1, ObtainMethod: "Real"},
+	}
+
+	d := Datadis{location: time.UTC}
+	acc := testutil.Accumulator{}
+	d.addPeriodSummary(&acc, metrics)
+
+	if len(acc.Metrics) != 2 {
+		t.Fatalf("expected: %d, got: %d", 2, len(acc.Metrics))
+	}
+
+	want := map[string]map[string]float64{
+		"1234": {"kwh_p1": 0.75, "kwh_p2": 0.125, "kwh_p3": 0.3},
+		"5678": {"kwh_p1": 1, "kwh_p2": 0, "kwh_p3": 0},
+	}
+	for _, m := range acc.Metrics {
+		if m.Measurement != "datadis_period_summary" {
+			t.Fatalf("expected: %q, got: %q", "datadis_period_summary", m.Measurement)
+		}
+		for field, value := range want[m.Tags["cups"]] {
+			if got := m.Fields[field].(float64); math.Abs(got-value) > 1e-9 {
+				t.Fatalf("expected: %v for %v of %v, got: %v", value, field, m.Tags["cups"], got)
+			}
+		}
+	}
+}
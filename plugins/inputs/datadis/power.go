@@ -0,0 +1,49 @@
+package datadis
+
+import (
+	"context"
+
+	"github.com/influxdata/telegraf"
+)
+
+// powerCurve asks get-consumption-data for the average power of every
+// interval, in the powerKW of the readings, instead of the energy consumed.
+const powerCurve measurementType = 2
+
+// fetchPower requests the power curve of supply like its consumption.
+func fetchPower(ctx context.Context, d *Datadis, supply Supply) ([]Consumption, error) {
+	return fetchReadings(ctx, d, supply, powerCurve)
+}
+
+// goPower schedules the power curve of every supply in group, collected into
+// power.
+func (d *Datadis) goPower(ctx context.Context, group *supplyGroup, power *[]Consumption) {
+	group.goEach(ctx, func(ctx context.Context, supply Supply) error {
+		data, err := fetchPower(ctx, d, supply)
+
+		group.locked(func() {
+			*power = append(*power, data...)
+		})
+		return err
+	})
+}
+
+func (d *Datadis) addPower(acc telegraf.Accumulator, power []Consumption) {
+	for _, reading := range power {
+		if reading.Date == "" || reading.Time == "" || !d.keepObtainMethod(reading.ObtainMethod) {
+			continue
+		}
+
+		timestamp, err := reading.timestamp(d.location)
+		if err != nil {
+			acc.AddError(err)
+			continue
+		}
+
+		tags := map[string]string{"cups": reading.Cups}
+		if reading.ObtainMethod != "" {
+			tags["obtain_method"] = normalizeObtainMethod(reading.ObtainMethod)
+		}
+		acc.AddFields(d.measurement("power"), map[string]interface{}{"kw": reading.PowerKW}, tags, *timestamp)
+	}
+}
@@ -0,0 +1,77 @@
+package datadis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestFetchPower(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api-private/api/get-consumption-data" {
+			t.Fatalf("unexpected path: %q", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("measurementType"); got != "2" {
+			t.Fatalf("expected: %q, got: %q", "2", got)
+		}
+
+		fmt.Fprint(rw, `[ {
+			"cups" : "1234",
+			"date" : "2021/12/28",
+			"time" : "01:00",
+			"powerKW" : 0.5,
+			"obtainMethod" : "Estimada"
+		  }, {
+			"cups" : "1234",
+			"date" : "2021/12/28",
+			"time" : "01:00",
+			"powerKW" : 0.484,
+			"obtainMethod" : "Real"
+		  }, {
+			"cups" : "1234",
+			"date" : "2021/12/28",
+			"time" : "02:00",
+			"powerKW" : "0.412",
+			"obtainMethod" : "Real"
+		  } ]`)
+	}))
+	defer ts.Close()
+
+	d := Datadis{
+		BaseURL:    ts.URL,
+		httpClient: ts.Client(),
+		StartDate:  "2021/12/28",
+		EndDate:    "2021/12/28",
+		PreferReal: true,
+		location:   time.UTC,
+		Log:        testutil.Logger{},
+	}
+
+	got, err := fetchPower(context.Background(), &d, Supply{Cups: "1234"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected: %d, got: %d", 2, len(got))
+	}
+
+	acc := testutil.Accumulator{}
+	d.addPower(&acc, got)
+
+	acc.AssertContainsTaggedFields(t, "datadis_power",
+		map[string]interface{}{"kw": 0.484},
+		map[string]string{"cups": "1234", "obtain_method": "real"})
+	acc.AssertContainsTaggedFields(t, "datadis_power",
+		map[string]interface{}{"kw": 0.412},
+		map[string]string{"cups": "1234", "obtain_method": "real"})
+
+	want := time.Date(2021, 12, 28, 1, 0, 0, 0, time.UTC)
+	if !acc.Metrics[0].Time.Equal(want) {
+		t.Fatalf("expected: %v, got: %v", want, acc.Metrics[0].Time)
+	}
+}
@@ -0,0 +1,58 @@
+package datadis
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultMaxRetryAfter bounds the Retry-After waits when max_retry_after is
+// unset.
+const defaultMaxRetryAfter = time.Minute
+
+// retryAfter returns the wait requested by the Retry-After header of a rate
+// limited resp, given in seconds or as an HTTP date.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if wait := date.Sub(now); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
+
+// retryWait returns how long to wait before retrying attempt after resp,
+// honoring the Retry-After of Datadis up to max_retry_after.
+func (d *Datadis) retryWait(attempt int, resp *http.Response) time.Duration {
+	wait, ok := retryAfter(resp, d.clock())
+	if !ok {
+		return d.backoff(attempt)
+	}
+
+	limit := time.Duration(d.MaxRetryAfter)
+	if limit <= 0 {
+		limit = defaultMaxRetryAfter
+	}
+	if wait > limit {
+		d.Log.Debugf("Retry-After exceeds max_retry_after%s", logContext("retry_after", wait, "wait", limit))
+		wait = limit
+	}
+	return wait
+}
@@ -0,0 +1,120 @@
+package datadis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestRetryAfter(t *testing.T) {
+	var requests []time.Time
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests = append(requests, time.Now())
+		if len(requests) == 1 {
+			rw.Header().Set("Retry-After", "1")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(rw, `[]`)
+	}))
+	defer ts.Close()
+
+	d := Datadis{
+		httpClient: ts.Client(),
+		MaxRetries: 1,
+		Log:        testutil.Logger{},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := d.send(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected: %v, got: %v", http.StatusOK, resp.StatusCode)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected: %d, got: %d", 2, len(requests))
+	}
+	if wait := requests[1].Sub(requests[0]); wait < time.Second {
+		t.Fatalf("expected a wait of at least %v, got: %v", time.Second, wait)
+	}
+
+	t.Run("Should give up when the wait outlives the gather", func(t *testing.T) {
+		requests = nil
+		ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			requests = append(requests, time.Now())
+			rw.Header().Set("Retry-After", "30")
+			rw.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer ts.Close()
+
+		d := Datadis{
+			httpClient: ts.Client(),
+			MaxRetries: 1,
+			Log:        testutil.Logger{},
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := d.send(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusTooManyRequests || len(requests) != 1 {
+			t.Fatalf("expected: a single rate limited request, got: %v after %d", resp.StatusCode, len(requests))
+		}
+	})
+}
+
+func TestRetryWait(t *testing.T) {
+	now := time.Date(2021, 12, 28, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		want       time.Duration
+	}{
+		{"Should wait the seconds of Retry-After", http.StatusTooManyRequests, "5", 5 * time.Second},
+		{"Should wait until the date of Retry-After", http.StatusTooManyRequests, now.Add(20 * time.Second).Format(http.TimeFormat), 20 * time.Second},
+		{"Should bound the wait to max_retry_after", http.StatusTooManyRequests, "3600", time.Minute},
+		{"Should back off without Retry-After", http.StatusTooManyRequests, "", 0},
+		{"Should back off on server errors", http.StatusInternalServerError, "5", 0},
+		{"Should back off on an invalid Retry-After", http.StatusTooManyRequests, "soon", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Datadis{
+				MaxRetryAfter: config.Duration(time.Minute),
+				now:           func() time.Time { return now },
+				Log:           testutil.Logger{},
+			}
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+
+			if got := d.retryWait(0, resp); got != tt.want {
+				t.Fatalf("expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
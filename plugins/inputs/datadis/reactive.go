@@ -0,0 +1,119 @@
+package datadis
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/influxdata/telegraf"
+)
+
+type (
+	// ReactiveEnergy is the reactive energy of a supply grouped by month.
+	ReactiveEnergy struct {
+		Cups   string           `json:"cups"`
+		Energy []ReactivePeriod `json:"energy"`
+	}
+
+	// ReactivePeriod holds the reactive energy, in kVArh, of each tariff
+	// period within a month.
+	ReactivePeriod struct {
+		Date     string  `json:"date"`
+		EnergyP1 float64 `json:"energy_p1"`
+		EnergyP2 float64 `json:"energy_p2"`
+		EnergyP3 float64 `json:"energy_p3"`
+		EnergyP4 float64 `json:"energy_p4"`
+		EnergyP5 float64 `json:"energy_p5"`
+		EnergyP6 float64 `json:"energy_p6"`
+	}
+)
+
+func (r *ReactivePeriod) timestamp(loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation(monthLayout, r.Date, loc)
+}
+
+func fetchReactiveEnergy(ctx context.Context, d *Datadis, supply Supply) (*ReactiveEnergy, error) {
+	reactiveURL := d.endpoint("get-reactive-data")
+
+	params := url.Values{
+		"cups":            {supply.Cups},
+		"distributorCode": {supply.DistributorCode},
+	}
+
+	start, end, err := d.dateRange()
+	if err != nil {
+		return nil, err
+	}
+	setDateRange(params, start, end, monthLayout)
+
+	d.addAuthorizedNif(params)
+	reactiveURL.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", reactiveURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	var data struct {
+		ReactiveEnergy ReactiveEnergy `json:"reactiveEnergy"`
+	}
+	if resp.StatusCode == 200 {
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, statusError("reactive energy", resp)
+	}
+
+	return &data.ReactiveEnergy, nil
+}
+
+// goReactiveEnergy schedules the reactive energy of every supply in group,
+// collected into reactive.
+func (d *Datadis) goReactiveEnergy(ctx context.Context, group *supplyGroup, reactive *[]ReactiveEnergy) {
+	group.goEach(ctx, func(ctx context.Context, supply Supply) error {
+		data, err := fetchReactiveEnergy(ctx, d, supply)
+		if err != nil {
+			return err
+		}
+
+		group.locked(func() {
+			*reactive = append(*reactive, *data)
+		})
+		return nil
+	})
+}
+
+func (d *Datadis) addReactiveEnergy(acc telegraf.Accumulator, reactive []ReactiveEnergy) {
+	for _, energy := range reactive {
+		tags := map[string]string{"cups": normalizeCups(energy.Cups)}
+
+		for _, period := range energy.Energy {
+			timestamp, err := period.timestamp(d.location)
+			if err != nil {
+				acc.AddError(err)
+				continue
+			}
+
+			fields := map[string]interface{}{
+				"kvarh_p1": period.EnergyP1,
+				"kvarh_p2": period.EnergyP2,
+				"kvarh_p3": period.EnergyP3,
+				"kvarh_p4": period.EnergyP4,
+				"kvarh_p5": period.EnergyP5,
+				"kvarh_p6": period.EnergyP6,
+			}
+			acc.AddFields(d.measurement("reactive"), fields, tags, timestamp)
+		}
+	}
+}
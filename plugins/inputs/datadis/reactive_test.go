@@ -0,0 +1,94 @@
+package datadis
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestFetchReactiveEnergy(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nikola-auth/tokens/login":
+			fmt.Fprint(rw, "token")
+			return
+		case "/api-private/api/get-consumption-data":
+			fmt.Fprint(rw, "[]")
+			return
+		}
+		if r.URL.Path != "/api-private/api/get-reactive-data" {
+			t.Fatalf("unexpected path: %q", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("startDate") != "2021/11" || query.Get("endDate") != "2021/12" {
+			t.Fatalf("unexpected date range: %q - %q", query.Get("startDate"), query.Get("endDate"))
+		}
+
+		fmt.Fprint(rw, `{
+			"reactiveEnergy" : {
+				"cups" : "1234",
+				"energy" : [ {
+					"date" : "2021/11",
+					"energy_p1" : 1.5,
+					"energy_p2" : 0.25,
+					"energy_p3" : 0.0,
+					"energy_p4" : 0.0,
+					"energy_p5" : 0.0,
+					"energy_p6" : 3.75
+				}, {
+					"date" : "2021/12",
+					"energy_p1" : 2.0,
+					"energy_p2" : 0.5,
+					"energy_p3" : 0.0,
+					"energy_p4" : 0.0,
+					"energy_p5" : 0.0,
+					"energy_p6" : 4.0
+				} ],
+				"code" : "200",
+				"code_desc" : "Ok"
+			}
+		}`)
+	}))
+	defer ts.Close()
+
+	d := Datadis{
+		BaseURL:        ts.URL,
+		Username:       "user",
+		Password:       "pass",
+		Timezone:       "Europe/Madrid",
+		StartDate:      "2021/11/01",
+		EndDate:        "2021/12/31",
+		Supplies:       []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}},
+		GatherReactive: true,
+		Log:            testutil.Logger{},
+	}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	acc := testutil.Accumulator{}
+	if err := d.Gather(&acc); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(acc.Metrics) != 2 {
+		t.Fatalf("expected: %d, got: %d", 2, len(acc.Metrics))
+	}
+
+	m := acc.Metrics[0]
+	if m.Measurement != "datadis_reactive" || m.Tags["cups"] != "1234" {
+		t.Fatalf("unexpected metric: %v", m)
+	}
+	if m.Fields["kvarh_p1"] != 1.5 || m.Fields["kvarh_p6"] != 3.75 {
+		t.Fatalf("unexpected fields: %v", m.Fields)
+	}
+
+	want := time.Date(2021, 10, 31, 23, 0, 0, 0, time.UTC)
+	if !m.Time.Equal(want) {
+		t.Fatalf("expected: %v, got: %v", want, m.Time.UTC())
+	}
+}
@@ -0,0 +1,60 @@
+package datadis
+
+import (
+	"time"
+
+	"github.com/influxdata/telegraf"
+)
+
+// renamer renames the tags and fields listed in tag_names and field_names
+// on every metric, to match the schema downstream.
+type renamer struct {
+	telegraf.Accumulator
+	tags   map[string]string
+	fields map[string]string
+}
+
+// newRenamer wraps acc, or returns it unchanged when nothing is renamed.
+func (d *Datadis) newRenamer(acc telegraf.Accumulator) telegraf.Accumulator {
+	if len(d.TagNames) == 0 && len(d.FieldNames) == 0 {
+		return acc
+	}
+	return &renamer{Accumulator: acc, tags: d.TagNames, fields: d.FieldNames}
+}
+
+func (r *renamer) AddFields(measurement string, fields map[string]interface{}, tags map[string]string, t ...time.Time) {
+	renamedFields := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		renamedFields[rename(r.fields, key)] = value
+	}
+
+	renamedTags := make(map[string]string, len(tags))
+	for key, value := range tags {
+		renamedTags[rename(r.tags, key)] = value
+	}
+	r.Accumulator.AddFields(measurement, renamedFields, renamedTags, t...)
+}
+
+func (r *renamer) AddMetric(m telegraf.Metric) {
+	for from, to := range r.tags {
+		if value, ok := m.GetTag(from); ok {
+			m.RemoveTag(from)
+			m.AddTag(to, value)
+		}
+	}
+	for from, to := range r.fields {
+		if value, ok := m.GetField(from); ok {
+			m.RemoveField(from)
+			m.AddField(to, value)
+		}
+	}
+	r.Accumulator.AddMetric(m)
+}
+
+// rename returns the new name of key in names, or key when it is unmapped.
+func rename(names map[string]string, key string) string {
+	if name, ok := names[key]; ok && name != "" {
+		return name
+	}
+	return key
+}
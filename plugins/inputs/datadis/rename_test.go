@@ -0,0 +1,69 @@
+package datadis
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestRenamer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/nikola-auth/tokens/login" {
+			fmt.Fprint(rw, "token")
+			return
+		}
+		fmt.Fprint(rw, `[ {
+			"cups" : "1234",
+			"date" : "2021/12/28",
+			"time" : "01:00",
+			"consumptionKWh" : 0.121,
+			"obtainMethod" : "Real"
+		  } ]`)
+	}))
+	defer ts.Close()
+
+	d := Datadis{
+		BaseURL:       ts.URL,
+		Username:      "user",
+		Password:      "pass",
+		Timezone:      Timezone,
+		StartDate:     "2021/12/28",
+		EndDate:       "2021/12/28",
+		Supplies:      []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}},
+		FieldNames:    map[string]string{"kwh": "energy_kwh"},
+		TagNames:      map[string]string{"cups": "supply"},
+		ExcludeTags:   []string{"obtain_method", "point_type", "resolution"},
+		ExcludeFields: []string{"is_estimated"},
+		Log:           testutil.Logger{},
+	}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	acc := testutil.Accumulator{}
+	if err := d.Gather(&acc); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(acc.Metrics) != 1 {
+		t.Fatalf("expected: %d, got: %d", 1, len(acc.Metrics))
+	}
+	acc.AssertContainsTaggedFields(t, "datadis_consumption",
+		map[string]interface{}{"energy_kwh": 0.121}, map[string]string{"supply": "1234"})
+
+	t.Run("Should rename the fields of metrics", func(t *testing.T) {
+		acc := testutil.Accumulator{}
+		r := d.newRenamer(&acc)
+		r.AddMetric(testutil.MustMetric("datadis_consumption",
+			map[string]string{"cups": "1234", "obtain_method": "real"},
+			map[string]interface{}{"kwh": 0.121, "is_estimated": false},
+			d.clock()))
+
+		acc.AssertContainsTaggedFields(t, "datadis_consumption",
+			map[string]interface{}{"energy_kwh": 0.121, "is_estimated": false},
+			map[string]string{"supply": "1234", "obtain_method": "real"})
+	})
+}
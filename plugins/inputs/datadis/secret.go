@@ -0,0 +1,56 @@
+package datadis
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Secret is a credential given either literally or as a reference to an
+// environment variable, "@{env:NAME}", resolved only when used so the value
+// stays out of the configuration. It is held in plain memory.
+type Secret string
+
+// Get resolves the secret.
+func (s Secret) Get() (string, error) {
+	value := string(s)
+	if !strings.HasPrefix(value, "@{") || !strings.HasSuffix(value, "}") {
+		return value, nil
+	}
+
+	reference := strings.TrimSuffix(strings.TrimPrefix(value, "@{"), "}")
+	parts := strings.SplitN(reference, ":", 2)
+	if len(parts) != 2 || parts[0] != "env" {
+		return "", fmt.Errorf("unknown secret reference %q: expected @{env:NAME}", value)
+	}
+
+	resolved, ok := os.LookupEnv(parts[1])
+	if !ok {
+		return "", fmt.Errorf("secret %q: environment variable %v is not set", value, parts[1])
+	}
+	return resolved, nil
+}
+
+// readPasswordFile sets the password to the contents of password_file, if
+// any, without the trailing newline.
+func (d *Datadis) readPasswordFile() error {
+	if d.PasswordFile == "" {
+		return nil
+	}
+	if d.Password != "" {
+		return errors.New("password and password_file cannot be set together")
+	}
+
+	data, err := os.ReadFile(d.PasswordFile)
+	if err != nil {
+		return fmt.Errorf("invalid password_file %q: %w", d.PasswordFile, err)
+	}
+	d.Password = Secret(strings.TrimRight(string(data), "\r\n"))
+	return nil
+}
+
+// GoString keeps the secret out of the debug logs.
+func (s Secret) GoString() string {
+	return `"[redacted]"`
+}
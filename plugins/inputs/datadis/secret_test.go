@@ -0,0 +1,115 @@
+package datadis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestSecret(t *testing.T) {
+	t.Setenv("DATADIS_TEST_PASSWORD", "s3cret")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("username") != "user" {
+			t.Fatalf("expected: %q, got: %q", "user", query.Get("username"))
+		}
+		if query.Get("password") != "s3cret" {
+			t.Fatalf("expected: %q, got: %q", "s3cret", query.Get("password"))
+		}
+		fmt.Fprint(rw, "token")
+	}))
+	defer ts.Close()
+
+	t.Run("Should resolve reference on login", func(t *testing.T) {
+		d := Datadis{
+			BaseURL:    ts.URL,
+			httpClient: ts.Client(),
+			Username:   "user",
+			Password:   "@{env:DATADIS_TEST_PASSWORD}",
+			Log:        testutil.Logger{},
+		}
+
+		if err := d.refreshToken(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if d.token != "token" {
+			t.Fatalf("expected: %q, got: %q", "token", d.token)
+		}
+	})
+	t.Run("Should fail on unset variable", func(t *testing.T) {
+		_, err := Secret("@{env:DATADIS_TEST_MISSING}").Get()
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("Should fail on unknown store", func(t *testing.T) {
+		_, err := Secret("@{vault:password}").Get()
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestPasswordFile(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("password"); got != "s3cret" {
+			t.Fatalf("expected: %q, got: %q", "s3cret", got)
+		}
+		fmt.Fprint(rw, "token")
+	}))
+	defer ts.Close()
+
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(passwordFile, []byte("s3cret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Should log in with the password of the file", func(t *testing.T) {
+		d := Datadis{
+			BaseURL:      ts.URL,
+			Username:     "user",
+			PasswordFile: passwordFile,
+			Supplies:     []Supply{{Cups: "1234", PointType: 5, DistributorCode: "2"}},
+			Log:          testutil.Logger{},
+		}
+		if err := d.Init(); err != nil {
+			t.Fatal(err)
+		}
+
+		d.httpClient = ts.Client()
+		if err := d.refreshToken(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if d.token != "token" {
+			t.Fatalf("expected: %q, got: %q", "token", d.token)
+		}
+	})
+	t.Run("Should fail with both password and password_file", func(t *testing.T) {
+		d := Datadis{
+			Username:     "user",
+			Password:     "pass",
+			PasswordFile: passwordFile,
+			Log:          testutil.Logger{},
+		}
+		if err := d.Init(); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("Should fail on a missing file", func(t *testing.T) {
+		d := Datadis{
+			Username:     "user",
+			PasswordFile: filepath.Join(t.TempDir(), "missing"),
+			Log:          testutil.Logger{},
+		}
+		if err := d.Init(); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
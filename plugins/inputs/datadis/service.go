@@ -0,0 +1,78 @@
+package datadis
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/telegraf"
+)
+
+// Start logs in and keeps the token fresh every token_refresh_interval, so
+// gathers don't wait for the login.
+func (d *Datadis) Start(acc telegraf.Accumulator) error {
+	for _, account := range d.accounts {
+		err := account.Start(&accountAccumulator{Accumulator: acc, account: account.accountName})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(d.accounts) > 0 || d.TokenRefreshInterval <= 0 {
+		return nil
+	}
+
+	if d.httpClient == nil {
+		client, err := d.createHTTPClient()
+		if err != nil {
+			return err
+		}
+		d.httpClient = client
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.stopRefresh = cancel
+	d.refreshing.Add(1)
+	go func() {
+		defer d.refreshing.Done()
+		d.refreshTokens(ctx, acc)
+	}()
+	return nil
+}
+
+// Stop ends the token refresh.
+func (d *Datadis) Stop() {
+	for _, account := range d.accounts {
+		account.Stop()
+	}
+
+	if d.stopRefresh != nil {
+		d.stopRefresh()
+		d.refreshing.Wait()
+	}
+}
+
+func (d *Datadis) refreshTokens(ctx context.Context, acc telegraf.Accumulator) {
+	renew := func() {
+		err := d.renewToken(ctx, d.currentToken())
+		if err != nil && ctx.Err() == nil {
+			acc.AddError(err)
+		}
+	}
+
+	// A token restored from token_cache_file is still valid.
+	if d.currentToken() == "" {
+		renew()
+	}
+
+	ticker := time.NewTicker(time.Duration(d.TokenRefreshInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			renew()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package datadis
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/config"
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestTokenRefresher(t *testing.T) {
+	var logins int32
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&logins, 1)
+		fmt.Fprint(rw, "token")
+	}))
+	defer ts.Close()
+
+	d := Datadis{
+		BaseURL:              ts.URL,
+		Username:             "user",
+		Password:             "pass",
+		Timezone:             Timezone,
+		TokenRefreshInterval: config.Duration(10 * time.Millisecond),
+		Log:                  testutil.Logger{},
+	}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	acc := testutil.Accumulator{}
+	if err := d.Start(&acc); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&logins) < 2 {
+		if time.Now().After(deadline) {
+			d.Stop()
+			t.Fatalf("expected: at least %d logins, got: %d", 2, atomic.LoadInt32(&logins))
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	d.Stop()
+	stopped := atomic.LoadInt32(&logins)
+	time.Sleep(50 * time.Millisecond)
+
+	if got := atomic.LoadInt32(&logins); got != stopped {
+		t.Fatalf("expected: %d logins after stop, got: %d", stopped, got)
+	}
+	if len(acc.Errors) != 0 {
+		t.Fatalf("expected: no errors, got: %v", acc.Errors)
+	}
+}
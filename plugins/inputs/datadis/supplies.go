@@ -0,0 +1,58 @@
+package datadis
+
+// mergeSupplies overrides the discovered supplies with the fields set in
+// the configured ones of the same CUPS. Configured supplies that weren't
+// discovered are kept.
+func mergeSupplies(configured, discovered []Supply) []Supply {
+	if len(configured) == 0 {
+		return discovered
+	}
+
+	overrides := map[string]Supply{}
+	for _, supply := range configured {
+		overrides[supply.Cups] = supply
+	}
+
+	merged := make([]Supply, 0, len(discovered)+len(configured))
+	for _, supply := range discovered {
+		if override, ok := overrides[supply.Cups]; ok {
+			supply = supply.override(override)
+			delete(overrides, supply.Cups)
+		}
+		merged = append(merged, supply)
+	}
+	for _, supply := range configured {
+		if _, ok := overrides[supply.Cups]; ok {
+			merged = append(merged, supply)
+		}
+	}
+	return merged
+}
+
+// override returns s with the fields set in o.
+func (s Supply) override(o Supply) Supply {
+	for _, field := range []struct{ dst, src *string }{
+		{&s.Address, &o.Address},
+		{&s.PostalCode, &o.PostalCode},
+		{&s.Province, &o.Province},
+		{&s.Municipality, &o.Municipality},
+		{&s.Distributor, &o.Distributor},
+		{&s.ValidDateFrom, &o.ValidDateFrom},
+		{&s.ValidDateTo, &o.ValidDateTo},
+		{&s.DistributorCode, &o.DistributorCode},
+	} {
+		if *field.src != "" {
+			*field.dst = *field.src
+		}
+	}
+	if o.PointType != 0 {
+		s.PointType = o.PointType
+	}
+	if len(o.DistributorCodes) > 0 {
+		s.DistributorCodes = o.DistributorCodes
+	}
+	if o.MeasurementType != nil {
+		s.MeasurementType = o.MeasurementType
+	}
+	return s
+}
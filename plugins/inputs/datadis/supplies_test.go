@@ -0,0 +1,87 @@
+package datadis
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestDiscoverSupplies(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nikola-auth/tokens/login":
+			fmt.Fprint(rw, "token")
+		case "/api-private/api/get-supplies":
+			fmt.Fprint(rw, `[
+				{"cups": "1", "address": "CALLE MAYOR 1", "distributorCode": "2", "pointType": 5},
+				{"cups": "2", "address": "CALLE MAYOR 2", "distributorCode": "2", "pointType": 5}
+			]`)
+		default:
+			fmt.Fprint(rw, "[]")
+		}
+	}))
+	defer ts.Close()
+
+	quarterHourly := QuarterHourly
+	d := Datadis{
+		BaseURL:          ts.URL,
+		Username:         "user",
+		Password:         "pass",
+		Timezone:         Timezone,
+		StartDate:        "2021/12/28",
+		EndDate:          "2021/12/28",
+		DiscoverSupplies: true,
+		Supplies: []Supply{
+			{Cups: "2", DistributorCode: "8", MeasurementType: &quarterHourly},
+			{Cups: "3", PointType: 4, DistributorCode: "2"},
+		},
+		Log: testutil.Logger{},
+	}
+	if err := d.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	acc := testutil.Accumulator{}
+	if err := d.Gather(&acc); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(d.Supplies) != 3 {
+		t.Fatalf("expected: %d, got: %+v", 3, d.Supplies)
+	}
+
+	discovered := d.Supplies[0]
+	if discovered.Cups != "1" || discovered.Address != "CALLE MAYOR 1" {
+		t.Fatalf("expected: discovered supply 1, got: %+v", discovered)
+	}
+
+	overridden := d.Supplies[1]
+	if overridden.Cups != "2" || overridden.DistributorCode != "8" || overridden.MeasurementType == nil || *overridden.MeasurementType != QuarterHourly {
+		t.Fatalf("expected: the overrides of supply 2, got: %+v", overridden)
+	}
+	if overridden.Address != "CALLE MAYOR 2" || overridden.PointType != 5 {
+		t.Fatalf("expected: the discovered fields of supply 2, got: %+v", overridden)
+	}
+
+	configured := d.Supplies[2]
+	if configured.Cups != "3" || configured.PointType != 4 {
+		t.Fatalf("expected: configured supply 3, got: %+v", configured)
+	}
+
+	t.Run("Should require the point type without discovery", func(t *testing.T) {
+		d := Datadis{
+			BaseURL:  ts.URL,
+			Username: "user",
+			Password: "pass",
+			Timezone: Timezone,
+			Supplies: []Supply{{Cups: "2"}},
+			Log:      testutil.Logger{},
+		}
+		if err := d.Init(); err == nil {
+			t.Fatal("expected: an error, got: none")
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package datadis
+
+import "time"
+
+// nationalHolidays are the fixed Spanish national holidays, month and day,
+// billed entirely in the cheapest period.
+var nationalHolidays = [][2]int{
+	{1, 1}, {1, 6}, {5, 1}, {8, 15}, {10, 12}, {11, 1}, {12, 6}, {12, 8}, {12, 25},
+}
+
+// tariffPeriod returns the 2.0TD energy period of the reading at t: P1
+// (punta), P2 (llano) or P3 (valle).
+func (d *Datadis) tariffPeriod(t time.Time) string {
+	// Readings cover the interval ending at their timestamp.
+	t = t.Add(-time.Nanosecond)
+
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday || d.isHoliday(t) {
+		return "P3"
+	}
+
+	switch hour := t.Hour(); {
+	case hour < 8:
+		return "P3"
+	case hour >= 10 && hour < 14, hour >= 18 && hour < 22:
+		return "P1"
+	default:
+		return "P2"
+	}
+}
+
+func (d *Datadis) isHoliday(t time.Time) bool {
+	for _, holiday := range nationalHolidays {
+		if int(t.Month()) == holiday[0] && t.Day() == holiday[1] {
+			return true
+		}
+	}
+
+	date := t.Format(dayLayout)
+	for _, holiday := range d.Holidays {
+		if holiday == date {
+			return true
+		}
+	}
+	return false
+}
+
+// price returns the configured price of period, warning once about periods
+// without a price.
+func (d *Datadis) price(period string) (float64, bool) {
+	price, ok := d.Prices[period]
+	if !ok && !d.missingPrices[period] {
+		if d.missingPrices == nil {
+			d.missingPrices = map[string]bool{}
+		}
+		d.missingPrices[period] = true
+		d.Log.Warnf("No price configured, skipping the cost%s", logContext("period", period))
+	}
+	return price, ok
+}
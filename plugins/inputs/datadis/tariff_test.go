@@ -0,0 +1,92 @@
+package datadis
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestTariffPeriod(t *testing.T) {
+	d := Datadis{location: time.UTC, TagTariffPeriod: true, Holidays: []string{"2021/03/19"}}
+
+	tests := []struct {
+		name string
+		date string
+		time string
+		want string
+	}{
+		{"Should tag weekday evening as punta", "2021/12/28", "20:00", "P1"},
+		{"Should tag weekday morning as llano", "2021/12/28", "09:00", "P2"},
+		{"Should tag weekday night as valle", "2021/12/28", "03:00", "P3"},
+		{"Should tag last hour of the day as llano", "2021/12/28", "24:00", "P2"},
+		{"Should tag weekend as valle", "2021/12/26", "20:00", "P3"},
+		{"Should tag national holiday as valle", "2021/12/08", "20:00", "P3"},
+		{"Should tag configured holiday as valle", "2021/03/19", "20:00", "P3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acc := testutil.Accumulator{}
+			err := d.aggregateMetrcs(&acc, []Consumption{
+				{Cups: "1234", Date: tt.date, Time: tt.time, KWh: 0.1, ObtainMethod: "Real"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(acc.Metrics) != 1 {
+				t.Fatalf("expected: %d, got: %d", 1, len(acc.Metrics))
+			}
+			if got := acc.Metrics[0].Tags["tariff_period"]; got != tt.want {
+				t.Fatalf("expected: %q, got: %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCost(t *testing.T) {
+	log := &recordingLogger{}
+	d := Datadis{
+		location: time.UTC,
+		Prices:   map[string]float64{"P1": 0.25, "P2": 0.17},
+		Log:      log,
+	}
+
+	acc := testutil.Accumulator{}
+	err := d.aggregateMetrcs(&acc, []Consumption{
+		{Cups: "1234", Date: "2021/12/28", Time: "20:00", KWh: 2, ObtainMethod: "Real"},
+		{Cups: "1234", Date: "2021/12/28", Time: "09:00", KWh: 2, ObtainMethod: "Real"},
+		{Cups: "1234", Date: "2021/12/28", Time: "03:00", KWh: 2, ObtainMethod: "Real"},
+		{Cups: "1234", Date: "2021/12/28", Time: "04:00", KWh: 2, ObtainMethod: "Real"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"20:00": 0.5,
+		"09:00": 0.34,
+		"03:00": nil,
+		"04:00": nil,
+	}
+	for _, m := range acc.Metrics {
+		hour := m.Time.Format("15:04")
+		cost, ok := m.Fields["cost_eur"]
+		if want[hour] == nil {
+			if ok {
+				t.Fatalf("expected no cost at %v, got: %v", hour, cost)
+			}
+			continue
+		}
+		if !ok || math.Abs(cost.(float64)-want[hour].(float64)) > 1e-9 {
+			t.Fatalf("expected: %v at %v, got: %v", want[hour], hour, cost)
+		}
+	}
+
+	if strings.Count(log.output(), "No price configured, skipping the cost period=P3") != 1 {
+		t.Fatalf("expected a single warning, got: %v", log.output())
+	}
+}
@@ -0,0 +1,54 @@
+package datadis
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+)
+
+// tokenLifetime is how long Datadis accepts a token after login.
+const tokenLifetime = 24 * time.Hour
+
+type cachedToken struct {
+	Token  string    `json:"token"`
+	Issued time.Time `json:"issued"`
+}
+
+// loadCachedToken restores the token persisted in token_cache_file, unless
+// it is missing or expired.
+func (d *Datadis) loadCachedToken() error {
+	data, err := os.ReadFile(d.TokenCacheFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var cached cachedToken
+	err = json.Unmarshal(data, &cached)
+	if err != nil {
+		return err
+	}
+
+	if d.clock().Sub(cached.Issued) >= tokenLifetime {
+		d.Log.Debug("Cached token expired")
+		return nil
+	}
+
+	d.setToken(cached.Token)
+
+	d.Log.Debug("Token loaded from cache")
+	return nil
+}
+
+// saveCachedToken persists token to token_cache_file, readable only by the
+// current user.
+func (d *Datadis) saveCachedToken(token string, issued time.Time) error {
+	data, err := json.Marshal(cachedToken{Token: token, Issued: issued})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(d.TokenCacheFile, data, 0600)
+}
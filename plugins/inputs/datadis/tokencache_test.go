@@ -0,0 +1,93 @@
+package datadis
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestTokenCache(t *testing.T) {
+	logins := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/nikola-auth/tokens/login" {
+			logins++
+			fmt.Fprint(rw, "fresh")
+			return
+		}
+		fmt.Fprint(rw, "[]")
+	}))
+	defer ts.Close()
+
+	newPlugin := func(cacheFile string) *Datadis {
+		d := &Datadis{
+			BaseURL:        ts.URL,
+			Username:       "user",
+			Password:       "pass",
+			Timezone:       Timezone,
+			TokenCacheFile: cacheFile,
+			Supplies:       []Supply{{Cups: "1234", PointType: 5}},
+			Log:            testutil.Logger{},
+		}
+		if err := d.Init(); err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	t.Run("Should reuse valid cached token", func(t *testing.T) {
+		cacheFile := filepath.Join(t.TempDir(), "token")
+		d := newPlugin(cacheFile)
+		if err := d.saveCachedToken("cached", time.Now().Add(-time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+
+		logins = 0
+		d = newPlugin(cacheFile)
+		if err := d.Gather(&testutil.Accumulator{}); err != nil {
+			t.Fatal(err)
+		}
+
+		if logins != 0 {
+			t.Fatalf("expected: %d logins, got: %d", 0, logins)
+		}
+		if d.token != "cached" {
+			t.Fatalf("expected: %q, got: %q", "cached", d.token)
+		}
+	})
+	t.Run("Should login and cache when expired", func(t *testing.T) {
+		cacheFile := filepath.Join(t.TempDir(), "token")
+		d := newPlugin(cacheFile)
+		if err := d.saveCachedToken("cached", time.Now().Add(-2*tokenLifetime)); err != nil {
+			t.Fatal(err)
+		}
+
+		logins = 0
+		d = newPlugin(cacheFile)
+		if err := d.Gather(&testutil.Accumulator{}); err != nil {
+			t.Fatal(err)
+		}
+
+		if logins != 1 {
+			t.Fatalf("expected: %d logins, got: %d", 1, logins)
+		}
+
+		info, err := os.Stat(cacheFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Fatalf("expected: %v, got: %v", os.FileMode(0600), info.Mode().Perm())
+		}
+
+		d = newPlugin(cacheFile)
+		if d.token != "fresh" {
+			t.Fatalf("expected: %q, got: %q", "fresh", d.token)
+		}
+	})
+}